@@ -0,0 +1,57 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"answer-comments/internal/api"
+	"answer-comments/internal/app"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/service"
+	"answer-comments/internal/ui"
+)
+
+// runAPI implements the "api" subcommand: the versioned JSON HTTP API over
+// the comment pipeline.
+func runAPI(args []string) int {
+	fs := flag.NewFlagSet("api", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("api.usage"))
+		fs.PrintDefaults()
+	}
+	addr := fs.String("addr", "127.0.0.1:8081", i18n.T("api.flag.addr"))
+	metricsAddr := fs.String("metrics-addr", "", i18n.T("flag.metrics_addr"))
+	fs.Parse(args)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	myApp, err := app.NewApp(ctx, true)
+	if err != nil {
+		ui.Error(i18n.T("cmd.init_error", err))
+		return 1
+	}
+	defer myApp.Close()
+
+	startMetrics(ctx, cmp.Or(*metricsAddr, myApp.Config.MetricsAddr))
+
+	commentService := service.NewCommentService(myApp)
+	commentService.LoadMembers(ctx)
+
+	server, err := api.NewServer(commentService, myApp.Config.APIToken)
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+
+	ui.Info(i18n.T("api.listening", *addr))
+	if err := server.ListenAndServe(ctx, *addr); err != nil {
+		ui.Error(i18n.T("api.server_error", err))
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/ui"
+	yt "answer-comments/internal/youtube"
+)
+
+// runAuth implements the "auth" subcommand: login, logout and status of the
+// YouTube authorization.
+func runAuth(args []string) int {
+	usage := func() {
+		fmt.Fprint(os.Stderr, i18n.T("auth.usage"))
+	}
+	if len(args) != 1 {
+		usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	oauthConfig, tokFile, err := app.OAuthConfig()
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+
+	switch args[0] {
+	case "login":
+		if _, err := yt.Login(ctx, oauthConfig, tokFile); err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		ui.Success(i18n.T("auth.login_done"))
+		return 0
+
+	case "logout":
+		if err := yt.Logout(ctx, tokFile); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				ui.Info(i18n.T("cmd.no_token", tokFile))
+				return 0
+			}
+			ui.Error(err.Error())
+			return 1
+		}
+		ui.Success(i18n.T("auth.logout_done"))
+		return 0
+
+	case "status":
+		tok, err := yt.LoadToken(tokFile)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				ui.Info(i18n.T("auth.not_authenticated", tokFile))
+				return 1
+			}
+			ui.Error(i18n.T("auth.unreadable", tokFile, err))
+			return 1
+		}
+		ui.PrintField(i18n.T("auth.file"), tokFile)
+		if tok.RefreshToken == "" {
+			ui.Warning(i18n.T("auth.no_refresh_token"))
+		}
+		if _, err := yt.CheckToken(ctx, oauthConfig, tokFile, tok); err != nil {
+			if errors.Is(err, yt.ErrTokenRevoked) {
+				ui.Error(i18n.T("auth.revoked"))
+			} else {
+				ui.Error(i18n.T("auth.validate_error", err))
+			}
+			ui.Info(i18n.T("auth.login_again"))
+			return 1
+		}
+		ui.Success(i18n.T("auth.valid"))
+		return 0
+
+	default:
+		usage()
+		return 2
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/service"
+	"answer-comments/internal/ui"
+)
+
+// runBackfill implements the "backfill" subcommand: imports the replies the
+// channel already published into the local history.
+func runBackfill(args []string) int {
+	fs := flag.NewFlagSet("backfill", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("backfill.usage"))
+		fs.PrintDefaults()
+	}
+	analyze := fs.Bool("analyze", false, i18n.T("backfill.flag.analyze"))
+	quota := fs.Int("quota", 1000, i18n.T("backfill.flag.quota"))
+	restart := fs.Bool("restart", false, i18n.T("backfill.flag.restart"))
+	fs.Parse(args)
+
+	if *quota < 1 {
+		ui.Error(i18n.T("backfill.quota_too_low"))
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	myApp, err := app.NewApp(ctx, false)
+	if err != nil {
+		ui.Error(i18n.T("cmd.init_error", err))
+		return 1
+	}
+	defer myApp.Close()
+
+	commentService := service.NewCommentService(myApp)
+	res, err := commentService.Backfill(ctx, service.BackfillOptions{
+		Analyze:     *analyze,
+		QuotaBudget: *quota,
+		Restart:     *restart,
+	}, func(r service.BackfillResult) {
+		ui.Muted(i18n.T("backfill.page", r.Pages, r.Answered, r.Imported, r.Existing))
+	})
+
+	if res.Resumed {
+		ui.Info(i18n.T("backfill.resumed"))
+	}
+	summary := i18n.T("backfill.summary",
+		res.Pages, res.Answered, res.Imported, res.Existing, res.QuotaUsed)
+	if *analyze {
+		summary += i18n.T("backfill.summary.analyzed", res.Analyzed)
+	}
+
+	switch {
+	case err != nil:
+		ui.Error(err.Error())
+		ui.Info(i18n.T("backfill.partial", summary))
+		switch {
+		case errors.Is(err, service.ErrQuotaExceeded):
+			return exitQuota
+		case errors.Is(err, service.ErrNetwork):
+			return exitNetwork
+		case errors.Is(err, service.ErrLLMUnavailable):
+			return exitLLM
+		}
+		return exitError
+	case res.Done:
+		ui.Success(i18n.T("backfill.done", summary))
+	default:
+		ui.Info(i18n.T("backfill.paused", summary))
+	}
+	return exitOK
+}
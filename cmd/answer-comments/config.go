@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/ui"
+
+	"gopkg.in/yaml.v3"
+)
+
+// runConfig implements the "config" subcommand: validation of the
+// configuration and display of the effective values.
+func runConfig(args []string) int {
+	fs := flag.NewFlagSet("config", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("config.usage"))
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 || (fs.Arg(0) != "check" && fs.Arg(0) != "show") {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, problems := app.CheckConfig()
+	if cfg != nil {
+		printConfig(cfg)
+	}
+	if fs.Arg(0) == "show" && cfg != nil {
+		return 0
+	}
+
+	failed := 0
+	for _, p := range problems {
+		if p.Severity == app.SeverityError {
+			failed++
+			ui.Error(p.String())
+		} else {
+			ui.Warning(p.String())
+		}
+	}
+	switch {
+	case failed > 0:
+		ui.Error(i18n.N("config.errors", failed))
+		return 1
+	case len(problems) > 0:
+		ui.Success(i18n.N("config.warnings", len(problems)))
+	default:
+		ui.Success(i18n.T("config.valid"))
+	}
+	return 0
+}
+
+// printConfig prints the effective configuration as YAML, with secrets
+// redacted and the files it came from as comments.
+func printConfig(cfg *app.Config) {
+	if cfg.Profile != "" {
+		fmt.Println(i18n.T("config.profile", cfg.Profile))
+	}
+	files := i18n.T("config.files.none")
+	if len(cfg.Files) > 0 {
+		files = strings.Join(cfg.Files, ", ")
+	}
+	fmt.Println(i18n.T("config.files", files))
+
+	enc := yaml.NewEncoder(os.Stdout)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg.Redacted()); err != nil {
+		ui.Error(i18n.T("config.print_error", err))
+	}
+	enc.Close()
+	fmt.Println()
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/costs"
+	"answer-comments/internal/database"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/ui"
+)
+
+// runCosts implements the "costs" subcommand: LLM spend per day and model,
+// computed from the llm_calls audit log.
+func runCosts(args []string) int {
+	fs := flag.NewFlagSet("costs", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("costs.usage"))
+		fs.PrintDefaults()
+	}
+	since := fs.String("since", "", i18n.T("costs.flag.since"))
+	by := fs.String("by", costs.ByDayModel, i18n.T("costs.flag.by"))
+	pricesFile := fs.String("prices", "", i18n.T("costs.flag.prices"))
+	asJSON := fs.Bool("json", false, i18n.T("costs.flag.json"))
+	fs.Parse(args)
+
+	switch *by {
+	case costs.ByDay, costs.ByModel, costs.ByDayModel:
+	default:
+		ui.Error(i18n.T("costs.unknown_grouping", *by))
+		return 2
+	}
+
+	from := time.Now().AddDate(0, 0, -30)
+	if *since != "" {
+		var err error
+		from, err = time.ParseInLocation("2006-01-02", *since, time.Local)
+		if err != nil {
+			ui.Error(i18n.T("cmd.invalid_since"))
+			return 2
+		}
+	}
+
+	myApp, err := app.NewLocalApp(context.Background())
+	if err != nil {
+		ui.Error(i18n.T("cmd.init_error", err))
+		return 1
+	}
+	defer myApp.Close()
+
+	prices, err := costs.LoadPrices(cmp.Or(*pricesFile, myApp.Config.LLM.PricesFile))
+	if err != nil {
+		ui.Error(i18n.T("costs.prices_error", err))
+		return 1
+	}
+
+	calls, err := database.ListLLMCalls(from)
+	if err != nil {
+		ui.Error(i18n.T("costs.calls_error", err))
+		return 1
+	}
+	report := costs.Summarize(calls, prices, *by)
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	if len(calls) == 0 {
+		ui.Info(i18n.T("costs.none"))
+		return 0
+	}
+	fmt.Printf("%s\n\n", i18n.T("costs.title", from.Format(i18n.T("cmd.date_layout"))))
+	costs.PrintReport(os.Stdout, report, *by)
+	return 0
+}
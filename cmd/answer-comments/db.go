@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/database"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/ui"
+)
+
+// runDB implements the "db" subcommand: maintenance of the local database.
+func runDB(args []string) int {
+	usage := func() {
+		fmt.Fprint(os.Stderr, i18n.T("db.usage"))
+	}
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+	switch args[0] {
+	case "info", "vacuum":
+		if len(args) != 1 {
+			usage()
+			return 2
+		}
+	case "backup":
+		if len(args) != 2 {
+			usage()
+			return 2
+		}
+	default:
+		usage()
+		return 2
+	}
+
+	myApp, err := app.NewLocalApp(context.Background())
+	if err != nil {
+		ui.Error(i18n.T("cmd.init_error", err))
+		return 1
+	}
+	defer myApp.Close()
+	path := myApp.Config.DatabaseFile
+
+	switch args[0] {
+	case "info":
+		counts, err := database.CountRows()
+		if err != nil {
+			ui.Error(i18n.T("db.read_error", err))
+			return 1
+		}
+		fmt.Printf("%s\n\n", i18n.T("db.info", path, fileSize(path)))
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, i18n.T("db.header"))
+		for _, c := range counts {
+			fmt.Fprintf(tw, "%s\t%d\n", c.Table, c.Rows)
+		}
+		tw.Flush()
+
+	case "backup":
+		if err := database.Backup(args[1]); err != nil {
+			ui.Error(i18n.T("db.backup_error", err))
+			return 1
+		}
+		ui.Success(i18n.T("db.backup_done", path, args[1], fileSize(args[1])))
+
+	case "vacuum":
+		before := fileSize(path)
+		if err := database.Vacuum(); err != nil {
+			ui.Error(i18n.T("db.vacuum_error", err))
+			return 1
+		}
+		ui.Success(i18n.T("db.vacuum_done", before, fileSize(path)))
+	}
+	return 0
+}
+
+// fileSize returns the size of the file at path for display, or "?" when it
+// cannot be read.
+func fileSize(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "?"
+	}
+	size := float64(info.Size())
+	for _, unit := range []string{"B", "KB", "MB"} {
+		if size < 1024 {
+			return fmt.Sprintf("%.0f %s", size, unit)
+		}
+		size /= 1024
+	}
+	return fmt.Sprintf("%.1f GB", size)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/eval"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/ui"
+)
+
+// runEval implements the "eval" subcommand: it runs the analysis and
+// generation prompts against a labeled dataset and reports their quality.
+func runEval(args []string) int {
+	fs := flag.NewFlagSet("eval", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("eval.usage"))
+		fs.PrintDefaults()
+	}
+	sample := fs.Int("sample", 0, i18n.T("eval.flag.sample"))
+	out := fs.String("out", "", i18n.T("eval.flag.out"))
+	fs.Parse(args)
+
+	ctx := context.Background()
+	myApp, err := app.NewLocalApp(ctx)
+	if err != nil {
+		ui.Error(i18n.T("cmd.init_error", err))
+		return 1
+	}
+	defer myApp.Close()
+
+	if *sample > 0 {
+		if *out == "" {
+			ui.Error(i18n.T("eval.out_required"))
+			return 2
+		}
+		cases, err := eval.SampleFromDB(*sample)
+		if err != nil {
+			ui.Error(i18n.T("eval.sample_error", err))
+			return 1
+		}
+		if err := eval.WriteDataset(*out, cases); err != nil {
+			ui.Error(i18n.T("eval.dataset_save_error", err))
+			return 1
+		}
+		ui.Success(i18n.T("eval.dataset_saved", len(cases), *out))
+		return 0
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	if myApp.LLM == nil {
+		ui.Error(i18n.T("cmd.gemini_key_missing"))
+		return 1
+	}
+
+	cases, err := eval.LoadDataset(fs.Arg(0))
+	if err != nil {
+		ui.Error(i18n.T("eval.dataset_error", err))
+		return 1
+	}
+
+	report := eval.Run(ctx, cases, myApp.LLM, func(i int, r eval.Result) {
+		status := "ok"
+		if r.Error != "" {
+			status = i18n.T("eval.case_error", r.Error)
+		}
+		ui.Muted(fmt.Sprintf("[%d/%d] %s — %s", i+1, len(cases), r.Case.ID, status))
+	})
+
+	fmt.Println()
+	eval.PrintReport(os.Stdout, report)
+
+	if *out != "" {
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = os.WriteFile(*out, b, 0644)
+		}
+		if err != nil {
+			ui.Error(i18n.T("eval.report_save_error", err))
+			return 1
+		}
+		ui.Success(i18n.T("eval.report_saved", *out))
+	}
+	return 0
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/database"
+	"answer-comments/internal/history"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/ui"
+)
+
+// runExport implements the "export" subcommand: writes the local history as
+// CSV or JSONL.
+func runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("export.usage"))
+		fs.PrintDefaults()
+	}
+	format := fs.String("format", history.JSONL, i18n.T("export.flag.format"))
+	since := fs.String("since", "", i18n.T("export.flag.since"))
+	theme := fs.String("theme", "", i18n.T("export.flag.theme"))
+	out := fs.String("out", "", i18n.T("export.flag.out"))
+	fs.Parse(args)
+
+	if *format != history.CSV && *format != history.JSONL {
+		ui.Error(i18n.T("export.unknown_format", *format))
+		return 2
+	}
+	filter := database.HistoryFilter{Theme: *theme}
+	if *since != "" {
+		from, err := time.ParseInLocation("2006-01-02", *since, time.Local)
+		if err != nil {
+			ui.Error(i18n.T("cmd.invalid_since"))
+			return 2
+		}
+		filter.Since = from
+	}
+
+	myApp, err := app.NewLocalApp(context.Background())
+	if err != nil {
+		ui.Error(i18n.T("cmd.init_error", err))
+		return 1
+	}
+	defer myApp.Close()
+
+	comments, err := database.ListComments(filter)
+	if err != nil {
+		ui.Error(i18n.T("cmd.history_error", err))
+		return 1
+	}
+	records := make([]history.Record, len(comments))
+	for i, c := range comments {
+		records[i] = history.FromDB(c)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			ui.Error(i18n.T("cmd.create_error", *out, err))
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := history.Write(w, *format, records); err != nil {
+		ui.Error(i18n.T("export.error", err))
+		return 1
+	}
+	if *out != "" {
+		ui.Success(i18n.T("export.done", len(records), *out))
+	}
+	return 0
+}
+
+// runImport implements the "import" subcommand: merges a file written by
+// export into the local history.
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("import.usage"))
+		fs.PrintDefaults()
+	}
+	format := fs.String("format", "", i18n.T("import.flag.format"))
+	onConflict := fs.String("on-conflict", string(database.ConflictSkip), i18n.T("import.flag.on_conflict"))
+	dryRun := fs.Bool("dry-run", false, i18n.T("import.flag.dry_run"))
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	if *format != history.CSV && *format != history.JSONL {
+		ui.Error(i18n.T("import.unknown_format", *format))
+		return 2
+	}
+	policy := database.ConflictPolicy(*onConflict)
+	switch policy {
+	case database.ConflictSkip, database.ConflictReplace, database.ConflictNewer:
+	default:
+		ui.Error(i18n.T("import.invalid_conflict", *onConflict))
+		return 2
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+	records, err := history.Read(f, *format)
+	f.Close()
+	if err != nil {
+		ui.Error(i18n.T("import.read_error", path, err))
+		return 1
+	}
+
+	myApp, err := app.NewLocalApp(context.Background())
+	if err != nil {
+		ui.Error(i18n.T("cmd.init_error", err))
+		return 1
+	}
+	defer myApp.Close()
+
+	comments := make([]database.DBComment, len(records))
+	for i, r := range records {
+		comments[i] = r.DB()
+	}
+	res, err := database.ImportComments(comments, policy, *dryRun)
+	if err != nil {
+		ui.Error(i18n.T("import.error", err))
+		return 1
+	}
+
+	summary := i18n.T("import.summary", res.Inserted, res.Replaced, res.Skipped, len(records))
+	if *dryRun {
+		ui.Info(i18n.T("import.dry_run", summary))
+	} else {
+		ui.Success(i18n.T("import.done", summary))
+	}
+	return 0
+}
@@ -1,99 +1,215 @@
 package main
 
 import (
+	"cmp"
 	"context"
-	"flag"
+	"errors"
 	"fmt"
-	"log"
 	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"answer-comments/internal/app"
-	"answer-comments/internal/debuglog"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/logging"
 	"answer-comments/internal/service"
+	"answer-comments/internal/tracing"
 	"answer-comments/internal/ui"
 )
 
+var logger = logging.For(logging.App)
+
+// logOptions is the logging section of the configuration, applied by
+// dispatch to every subcommand; the session flags override it.
+var logOptions logging.Options
+
 func main() {
-	// Customize flag usage message
-	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "YouTube Answer Comments - Assistente inteligente para responder comentários\n\n")
-		fmt.Fprintf(os.Stderr, "Esta ferramenta monitora comentários não respondidos no seu canal do YouTube\n")
-		fmt.Fprintf(os.Stderr, "e sugere respostas usando IA (Gemini), considerando o contexto do vídeo,\n")
-		fmt.Fprintf(os.Stderr, "histórico de interações e respostas anteriores similares.\n\n")
-		fmt.Fprintf(os.Stderr, "USO:\n")
-		fmt.Fprintf(os.Stderr, "  answer-comments [opções]\n\n")
-		fmt.Fprintf(os.Stderr, "OPÇÕES:\n")
-		flag.PrintDefaults()
-		fmt.Fprintf(os.Stderr, "\nREQUISITOS:\n")
-		fmt.Fprintf(os.Stderr, "  - client_secret.json: Credenciais OAuth2 do YouTube API\n")
-		fmt.Fprintf(os.Stderr, "  - GEMINI_API_KEY: Variável de ambiente com a chave da API Gemini\n")
-		fmt.Fprintf(os.Stderr, "  - members.csv (opcional): Lista de membros do canal\n\n")
-		fmt.Fprintf(os.Stderr, "EXEMPLOS:\n")
-		fmt.Fprintf(os.Stderr, "  answer-comments              # Modo padrão com sugestões da IA\n")
-		fmt.Fprintf(os.Stderr, "  answer-comments -d           # Modo de debug (salva arquivo de debug)\n")
-		fmt.Fprintf(os.Stderr, "  answer-comments -m           # Modo manual (sem sugestões)\n")
-		fmt.Fprintf(os.Stderr, "  answer-comments -a           # Modo automático (publica sem confirmação)\n")
-		fmt.Fprintf(os.Stderr, "  answer-comments -t           # Usa transcrição dos vídeos como contexto\n")
-		fmt.Fprintf(os.Stderr, "  answer-comments -a -t        # Combina modo automático com transcrição\n\n")
-	}
-
-	// Parse command line flags
-	manualMode := flag.Bool("manual", false, "Modo manual: pula a sugestão da LLM e força edição manual de todas as respostas")
-	flag.BoolVar(manualMode, "m", false, "Atalho para --manual")
-	autoAnswerMode := flag.Bool("auto", false, "Modo auto-resposta: todas as respostas sugeridas e com alto nível de confiança pela LLM serão publicadas automaticamente sem confirmação")
-	flag.BoolVar(autoAnswerMode, "a", false, "Atalho para --auto")
-	transcriptionMode := flag.Bool("transcription", false, "Modo transcrição: usa a transcrição automática do vídeo como contexto para a LLM (exceto para comentários de Saudação/Agradecimento)")
-	flag.BoolVar(transcriptionMode, "t", false, "Atalho para --transcription")
-	debugMode := flag.Bool("debug", false, "Ativa logging de debug em debug.log (ou caminho configurado com --debug-log)")
-	flag.BoolVar(debugMode, "d", false, "Atalho para --debug")
-	debugLogPath := flag.String("debug-log", "debug.log", "Caminho do arquivo de log de debug (requer --debug)")
-	flag.Parse()
-
-	if *debugMode {
-		if err := debuglog.Init(*debugLogPath); err != nil {
-			log.Printf("Aviso: %v", err)
-		}
-	}
+	os.Exit(dispatch())
+}
 
-	ui.ClearScreen()
+// command is a subcommand of the CLI. Each one parses its own flags and
+// prints its own help with -h. Its arguments and summary in the command list
+// are the catalog messages cmd.<name>.args and cmd.<name>.
+type command struct {
+	name string
+	run  func(args []string) int
+}
 
-	if *manualMode {
-		ui.PrintModeBanner("✏️", "Modo Manual Ativado", "Todas as respostas deverão ser editadas manualmente.", ui.FgBrightYellow)
-	}
-	if *autoAnswerMode {
-		ui.PrintModeBanner("🤖", "Modo Auto-Resposta Ativado", "Respostas com alto nível de confiança serão publicadas automaticamente.", ui.FgBrightGreen)
+// commands lists the subcommands in the order of the help.
+var commands = []command{
+	{"review", runReview},
+	{"run", runRun},
+	{"watch", runWatch},
+	{"serve", runServe},
+	{"api", runAPI},
+	{"stats", runStats},
+	{"search", runSearch},
+	{"export", runExport},
+	{"import", runImport},
+	{"backfill", runBackfill},
+	{"db", runDB},
+	{"costs", runCosts},
+	{"eval", runEval},
+	{"auth", runAuth},
+	{"profiles", runProfiles},
+	{"secrets", runSecrets},
+	{"config", runConfig},
+}
+
+func dispatch() int {
+	defer logging.Close()
+
+	// --lang selects the language of the messages and --profile the channel
+	// for every subcommand
+	lang, args, err := extractOption(os.Args[1:], "lang")
+	var profile string
+	if err == nil {
+		profile, args, err = extractOption(args, "profile")
 	}
-	if *transcriptionMode {
-		ui.PrintModeBanner("🎙️", "Modo Transcrição Ativado", "A transcrição dos vídeos será usada como contexto para a LLM.", ui.FgBrightCyan)
+	if err == nil {
+		err = app.SetProfile(profile)
 	}
-	if *debugMode {
-		ui.PrintModeBanner("⚠️", "Modo de Debug Ativado", "Arquivo de debug será salvo.", ui.FgBrightRed)
+	if err != nil {
+		ui.Error(err.Error())
+		return exitError
 	}
 
-	ctx := context.Background()
+	// Language, logging and tracing come from the configuration (config.yaml,
+	// config.env, the profile or the environment). When it cannot be read the
+	// defaults are used here and the command reports the error when it loads
+	// the configuration.
+	var traceOptions tracing.Options
+	cfg, cfgErr := app.ReadConfig()
+	if cfgErr == nil {
+		lang = cmp.Or(lang, cfg.Lang)
+		logOptions, traceOptions = cfg.Logging, cfg.Tracing
+	}
+	if err := setLocale(lang); err != nil {
+		ui.Error(err.Error())
+		return exitError
+	}
+	if err := logging.Setup(logOptions); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if cfgErr != nil {
+		logger.Debug("configuração ilegível; usando idioma, log e tracing padrão", logging.KeyError, cfgErr)
+	}
 
-	// Initialize App
-	myApp, err := app.NewApp(ctx, *transcriptionMode)
+	shutdownTracing, err := tracing.Setup(context.Background(), traceOptions)
 	if err != nil {
-		log.Printf("Erro ao inicializar aplicação: %v", err)
-		os.Exit(1)
+		logger.Warn("tracing desativado", logging.KeyError, err)
 	}
-	defer myApp.Close()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Warn("erro ao exportar traces pendentes", logging.KeyError, err)
+		}
+	}()
 
-	ui.Success("Autenticado com sucesso! ID do seu canal: " + myApp.ChannelID)
+	// Without a command (only flags, as before subcommands existed) the
+	// terminal review runs
+	if len(args) == 0 || (strings.HasPrefix(args[0], "-") && !isHelp(args[0])) {
+		return runReview(args)
+	}
+	if isHelp(args[0]) {
+		return runHelp(args[1:])
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(args[1:])
+		}
+	}
+	ui.Error(i18n.T("cmd.unknown", args[0]))
+	usage()
+	return 2
+}
+
+// setLocale selects the locale named by --lang or the configuration, or the
+// one of the system when neither sets it. The action keys switch only with an
+// explicit choice, so LANG alone does not change them.
+func setLocale(name string) error {
+	if name == "" {
+		i18n.Set(i18n.Detect())
+		return nil
+	}
+	l, ok := i18n.Parse(name)
+	if !ok {
+		return fmt.Errorf("idioma %q não suportado (use pt-BR ou en)", name)
+	}
+	i18n.Set(l)
+	i18n.SetKeys(l)
+	return nil
+}
+
+func isHelp(arg string) bool {
+	switch arg {
+	case "help", "-h", "-help", "--help":
+		return true
+	}
+	return false
+}
 
-	// Initialize Service
-	commentService := service.NewCommentService(myApp)
+// runHelp prints the general help, or the help of the named command.
+func runHelp(args []string) int {
+	if len(args) == 0 {
+		usage()
+		return 0
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run([]string{"-h"})
+		}
+	}
+	ui.Error(i18n.T("cmd.unknown", args[0]))
+	usage()
+	return 2
+}
 
-	// Start processing
-	opts := service.AnswerOptions{
-		ManualMode:        *manualMode,
-		AutoAnswerMode:    *autoAnswerMode,
-		TranscriptionMode: *transcriptionMode,
+// usage prints the general help: the command list and the global options.
+func usage() {
+	fmt.Fprint(os.Stderr, i18n.T("usage.intro"))
+	tw := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	for _, c := range commands {
+		fmt.Fprintf(tw, "  %s %s\t%s\n", c.name, i18n.T("cmd."+c.name+".args"), i18n.T("cmd."+c.name))
 	}
+	tw.Flush()
+	fmt.Fprint(os.Stderr, i18n.T("usage.outro"))
+}
+
+// Exit codes of the review session, so scripts and schedulers can tell why
+// it stopped.
+const (
+	exitOK      = 0
+	exitError   = 1
+	exitNetwork = 3
+	exitQuota   = 4
+	exitLLM     = 5
+)
+
+// exitCodesHelp describes the exit codes in the help of the commands that
+// process comments.
+func exitCodesHelp() string {
+	return i18n.T("usage.exit_codes", ui.Key(ui.ActionQuit))
+}
 
-	if err := commentService.ProcessComments(ctx, opts); err != nil {
-		log.Printf("Erro durante o processamento: %v", err)
-		os.Exit(1)
+// exitCode logs why a session ended and returns the matching exit code.
+func exitCode(err error) int {
+	switch {
+	case err == nil, errors.Is(err, service.ErrUserQuit):
+		return exitOK
+	case errors.Is(err, service.ErrNetwork):
+		logger.Error("erro de rede — abortando", logging.KeyError, err)
+		return exitNetwork
+	case errors.Is(err, service.ErrQuotaExceeded):
+		logger.Error("cota da API do YouTube esgotada — abortando", logging.KeyError, err)
+		return exitQuota
+	case errors.Is(err, service.ErrLLMUnavailable):
+		logger.Error("Gemini indisponível — abortando", logging.KeyError, err)
+		return exitLLM
+	default:
+		logger.Error("erro durante o processamento", logging.KeyError, err)
+		return exitError
 	}
 }
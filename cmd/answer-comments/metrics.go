@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/logging"
+	"answer-comments/internal/metrics"
+	"answer-comments/internal/ui"
+)
+
+// startMetrics serves /metrics on addr in the background until ctx ends. An
+// empty addr disables it.
+func startMetrics(ctx context.Context, addr string) {
+	if addr == "" {
+		return
+	}
+	go func() {
+		if err := metrics.ListenAndServe(ctx, addr); err != nil {
+			logger.Error("erro no servidor de métricas", logging.KeyError, err)
+		}
+	}()
+	ui.Info(i18n.T("metrics.available", addr))
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/secrets"
+	"answer-comments/internal/ui"
+	yt "answer-comments/internal/youtube"
+)
+
+// extractOption removes the global option --name from args (in any position
+// before "--") and returns its value, empty when it is absent.
+func extractOption(args []string, name string) (value string, rest []string, err error) {
+	rest = make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		opt, v, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || opt != name {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return "", nil, errors.New(i18n.T("cmd.option_needs_value", name))
+			}
+			i++
+			v = args[i]
+		}
+		value = v
+	}
+	return value, rest, nil
+}
+
+// runProfiles implements the "profiles" subcommand: lists the profiles with
+// their token, database and authorization status.
+func runProfiles(args []string) int {
+	fs := flag.NewFlagSet("profiles", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("profiles.usage"))
+		fs.PrintDefaults()
+	}
+	offline := fs.Bool("offline", false, i18n.T("profiles.flag.offline"))
+	fs.Parse(args)
+
+	names, err := app.ListProfiles()
+	if err != nil {
+		ui.Error(i18n.T("profiles.list_error", err))
+		return 1
+	}
+	if len(names) == 0 {
+		ui.Info(i18n.T("profiles.none"))
+	}
+
+	ctx, stop := context.WithTimeout(context.Background(), time.Minute)
+	defer stop()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, i18n.T("profiles.header"))
+	for _, name := range append([]string{""}, names...) {
+		label := name
+		if label == "" {
+			label = i18n.T("profiles.default")
+		}
+		cfg, err := app.ProfileConfig(name)
+		if err != nil {
+			fmt.Fprintf(tw, "%s\t-\t-\t%s\n", label, i18n.T("profiles.invalid_config", err))
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", label, cfg.TokenFile, cfg.DatabaseFile, authStatus(ctx, cfg, *offline))
+	}
+	tw.Flush()
+	return 0
+}
+
+// authStatus summarizes the token of cfg; unless offline, it is checked with
+// Google (see yt.CheckToken) to tell a revoked authorization apart.
+func authStatus(ctx context.Context, cfg *app.Config, offline bool) string {
+	if err := secrets.Setup(cfg.Secrets); err != nil {
+		return i18n.T("profiles.status.secrets_invalid")
+	}
+	tok, err := yt.LoadToken(cfg.TokenFile)
+	if err != nil {
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			return i18n.T("profiles.status.not_authenticated")
+		case errors.Is(err, secrets.ErrNoKey), errors.Is(err, secrets.ErrDecrypt):
+			return i18n.T("profiles.status.encrypted")
+		}
+		return i18n.T("profiles.status.unreadable")
+	}
+	if offline {
+		return i18n.T("profiles.status.saved")
+	}
+	oauthConfig, err := app.NewOAuthConfig(cfg)
+	if err != nil {
+		return i18n.T("profiles.status.client_secret_invalid")
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if _, err := yt.CheckToken(ctx, oauthConfig, cfg.TokenFile, tok); err != nil {
+		if errors.Is(err, yt.ErrTokenRevoked) {
+			return i18n.T("profiles.status.revoked")
+		}
+		return i18n.T("profiles.status.error")
+	}
+	return i18n.T("profiles.status.valid")
+}
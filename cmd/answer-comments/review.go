@@ -0,0 +1,209 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/logging"
+	"answer-comments/internal/service"
+	"answer-comments/internal/ui"
+)
+
+// sessionFlags are the options shared by the commands that process the
+// channel's comments (review, run and watch).
+type sessionFlags struct {
+	transcription bool
+	debug         bool
+	debugLog      string
+	log           logging.Options
+	metricsAddr   string
+}
+
+func (f *sessionFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.transcription, "transcription", false, i18n.T("flag.transcription"))
+	fs.BoolVar(&f.transcription, "t", false, i18n.T("flag.shortcut", "transcription"))
+	fs.BoolVar(&f.debug, "debug", false, i18n.T("flag.debug"))
+	fs.BoolVar(&f.debug, "d", false, i18n.T("flag.shortcut", "debug"))
+	fs.StringVar(&f.debugLog, "debug-log", "debug.log", i18n.T("flag.debug_log"))
+	f.log = logOptions
+	fs.StringVar(&f.log.Level, "log-level", f.log.Level, i18n.T("flag.log_level"))
+	fs.StringVar(&f.log.Format, "log-format", f.log.Format, i18n.T("flag.log_format"))
+	fs.StringVar(&f.log.File, "log-file", f.log.File, i18n.T("flag.log_file"))
+	fs.StringVar(&f.metricsAddr, "metrics-addr", "", i18n.T("flag.metrics_addr"))
+}
+
+// setupLogging applies the log flags; --debug turns on debug logs in the
+// debug file unless a level or file was given.
+func (f *sessionFlags) setupLogging() {
+	if f.debug {
+		if f.log.Level == "" {
+			f.log.Level = "debug"
+		}
+		if f.log.File == "" {
+			f.log.File = f.debugLog
+		}
+	}
+	if err := logging.Setup(f.log); err != nil {
+		logger.Warn("configuração de log inválida", logging.KeyError, err)
+	}
+}
+
+// newApp authenticates with YouTube and starts the metrics endpoint.
+func (f *sessionFlags) newApp(ctx context.Context) (*app.App, error) {
+	myApp, err := app.NewApp(ctx, f.transcription)
+	if err != nil {
+		return nil, err
+	}
+	ui.Success(i18n.T("session.authenticated", myApp.ChannelID))
+	if myApp.Config.Profile != "" {
+		ui.Info(i18n.T("session.profile", myApp.Config.Profile))
+	}
+	startMetrics(ctx, cmp.Or(f.metricsAddr, myApp.Config.MetricsAddr))
+	return myApp, nil
+}
+
+// runReview implements the "review" subcommand, also run when no command is
+// given: the interactive review session in the terminal. It returns the
+// process exit code and never calls os.Exit itself, so deferred cleanup
+// (closing the database) always runs.
+func runReview(args []string) int {
+	fs := flag.NewFlagSet("review", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("review.usage"))
+		fs.PrintDefaults()
+		fmt.Fprint(os.Stderr, i18n.T("review.usage.examples"))
+		fmt.Fprintf(os.Stderr, "\n%s\n", exitCodesHelp())
+	}
+	manualMode := fs.Bool("manual", false, i18n.T("flag.manual"))
+	fs.BoolVar(manualMode, "m", false, i18n.T("flag.shortcut", "manual"))
+	autoAnswerMode := fs.Bool("auto", false, i18n.T("flag.auto"))
+	fs.BoolVar(autoAnswerMode, "a", false, i18n.T("flag.shortcut", "auto"))
+	var session sessionFlags
+	session.register(fs)
+	fs.Parse(args)
+
+	session.setupLogging()
+
+	ui.ClearScreen()
+
+	if *manualMode {
+		ui.PrintModeBanner("✏️", i18n.T("banner.manual"), i18n.T("banner.manual.desc"), ui.FgBrightYellow)
+	}
+	if *autoAnswerMode {
+		ui.PrintModeBanner("🤖", i18n.T("banner.auto"), i18n.T("banner.auto.desc"), ui.FgBrightGreen)
+	}
+	if session.transcription {
+		ui.PrintModeBanner("🎙️", i18n.T("banner.transcription"), i18n.T("banner.transcription.desc"), ui.FgBrightCyan)
+	}
+	if session.debug {
+		ui.PrintModeBanner("⚠️", i18n.T("banner.debug"), i18n.T("banner.debug.desc"), ui.FgBrightRed)
+	}
+
+	ctx := context.Background()
+
+	myApp, err := session.newApp(ctx)
+	if err != nil {
+		logger.Error("erro ao inicializar aplicação", logging.KeyError, err)
+		return exitError
+	}
+	defer myApp.Close()
+
+	commentService := service.NewCommentService(myApp)
+	opts := service.AnswerOptions{
+		ManualMode:        *manualMode,
+		AutoAnswerMode:    *autoAnswerMode,
+		TranscriptionMode: session.transcription,
+	}
+	return exitCode(commentService.ProcessComments(ctx, opts))
+}
+
+// runRun implements the "run" subcommand: one unattended pass over the
+// unanswered comments, for schedulers.
+func runRun(args []string) int {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("run.usage"))
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\n%s\n", exitCodesHelp())
+	}
+	var session sessionFlags
+	session.register(fs)
+	fs.Parse(args)
+
+	session.setupLogging()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	myApp, err := session.newApp(ctx)
+	if err != nil {
+		logger.Error("erro ao inicializar aplicação", logging.KeyError, err)
+		return exitError
+	}
+	defer myApp.Close()
+
+	commentService := service.NewCommentService(myApp)
+	opts := service.AnswerOptions{TranscriptionMode: session.transcription, Unattended: true}
+	err = commentService.ProcessComments(ctx, opts)
+	// Ctrl+C is a deliberate stop, not a failure of the pass
+	if ctx.Err() != nil {
+		return exitOK
+	}
+	return exitCode(err)
+}
+
+// runWatch implements the "watch" subcommand: unattended passes repeated at
+// a fixed interval until interrupted.
+func runWatch(args []string) int {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("watch.usage"))
+		fs.PrintDefaults()
+	}
+	interval := fs.Duration("interval", 15*time.Minute, i18n.T("flag.interval"))
+	var session sessionFlags
+	session.register(fs)
+	fs.Parse(args)
+
+	if *interval < time.Minute {
+		ui.Error(i18n.T("watch.interval_too_short"))
+		return 2
+	}
+	session.setupLogging()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	myApp, err := session.newApp(ctx)
+	if err != nil {
+		logger.Error("erro ao inicializar aplicação", logging.KeyError, err)
+		return exitError
+	}
+	defer myApp.Close()
+
+	commentService := service.NewCommentService(myApp)
+	opts := service.AnswerOptions{TranscriptionMode: session.transcription, Unattended: true}
+	for {
+		err := commentService.ProcessComments(ctx, opts)
+		if ctx.Err() != nil {
+			return exitOK
+		}
+		if err != nil {
+			logger.Error("verificação interrompida; nova tentativa no próximo intervalo", logging.KeyError, err)
+		}
+
+		ui.Info(i18n.T("watch.next", time.Now().Add(*interval).Format("15:04")))
+		select {
+		case <-ctx.Done():
+			return exitOK
+		case <-time.After(*interval):
+		}
+	}
+}
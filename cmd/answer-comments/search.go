@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/database"
+	"answer-comments/internal/history"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/ui"
+)
+
+// runSearch implements the "search" subcommand: finds comments and answers
+// in the local history.
+func runSearch(args []string) int {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("search.usage"))
+		fs.PrintDefaults()
+	}
+	author := fs.String("author", "", i18n.T("search.flag.author"))
+	theme := fs.String("theme", "", i18n.T("flag.theme"))
+	sentiment := fs.String("sentiment", "", i18n.T("search.flag.sentiment"))
+	video := fs.String("video", "", i18n.T("search.flag.video"))
+	since := fs.String("since", "", i18n.T("search.flag.since"))
+	limit := fs.Int("limit", 20, i18n.T("search.flag.limit"))
+	asJSON := fs.Bool("json", false, i18n.T("search.flag.json"))
+	fs.Parse(args)
+
+	filter := database.HistoryFilter{
+		Author:    *author,
+		Theme:     *theme,
+		Sentiment: *sentiment,
+		VideoID:   *video,
+		Text:      strings.Join(fs.Args(), " "),
+		Limit:     *limit,
+	}
+	if *since != "" {
+		from, err := time.ParseInLocation("2006-01-02", *since, time.Local)
+		if err != nil {
+			ui.Error(i18n.T("cmd.invalid_since"))
+			return 2
+		}
+		filter.Since = from
+	}
+
+	myApp, err := app.NewLocalApp(context.Background())
+	if err != nil {
+		ui.Error(i18n.T("cmd.init_error", err))
+		return 1
+	}
+	defer myApp.Close()
+
+	comments, err := database.ListComments(filter)
+	if err != nil {
+		ui.Error(i18n.T("cmd.history_error", err))
+		return 1
+	}
+
+	if *asJSON {
+		records := make([]history.Record, len(comments))
+		for i, c := range comments {
+			records[i] = history.FromDB(c)
+		}
+		if err := history.Write(os.Stdout, history.JSONL, records); err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	if len(comments) == 0 {
+		ui.Info(i18n.T("search.none"))
+		return 0
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, i18n.T("search.header"))
+	for _, c := range comments {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			c.RespondedAt.Local().Format("2006-01-02"), c.Author, c.Theme, excerpt(c.CommentText, 50), excerpt(c.Response, 50))
+	}
+	tw.Flush()
+	if *limit > 0 && len(comments) == *limit {
+		ui.Muted(i18n.T("search.limited", *limit))
+	}
+	return 0
+}
+
+// excerpt returns s on a single line, cut to at most n runes.
+func excerpt(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/secrets"
+	"answer-comments/internal/ui"
+	yt "answer-comments/internal/youtube"
+
+	"golang.org/x/term"
+)
+
+// runSecrets implements the "secrets" subcommand: key generation, encryption
+// of settings and migration of the saved token.
+func runSecrets(args []string) int {
+	usage := func() {
+		fmt.Fprint(os.Stderr, i18n.T("secrets.usage"))
+	}
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+
+	switch args[0] {
+	case "keygen":
+		if len(args) != 2 {
+			usage()
+			return 2
+		}
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		f, err := os.OpenFile(args[1], os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			ui.Error(i18n.T("secrets.key_file_error", err))
+			return 1
+		}
+		fmt.Fprintln(f, base64.StdEncoding.EncodeToString(key))
+		if err := f.Close(); err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		ui.Success(i18n.T("secrets.keygen_done", args[1], args[1]))
+		return 0
+
+	case "encrypt":
+		if _, err := app.LoadConfig(); err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		if !secrets.Encrypted() {
+			ui.Error(i18n.T("secrets.no_key"))
+			return 1
+		}
+		value, err := readSecret(i18n.T("secrets.value_prompt"))
+		if err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		enc, err := secrets.Encrypt(value)
+		if err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		fmt.Println(enc)
+		return 0
+
+	case "set":
+		if len(args) != 2 {
+			usage()
+			return 2
+		}
+		value, err := readSecret(i18n.T("secrets.value_of_prompt", args[1]))
+		if err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		if err := secrets.KeyringSet(args[1], value); err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		ui.Success(i18n.T("secrets.set_done", args[1], args[1]))
+		return 0
+
+	case "migrate":
+		cfg, err := app.LoadConfig()
+		if err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		tok, err := yt.LoadToken(cfg.TokenFile)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				ui.Info(i18n.T("cmd.no_token", cfg.TokenFile))
+				return 0
+			}
+			ui.Error(err.Error())
+			return 1
+		}
+		if err := yt.SaveToken(cfg.TokenFile, tok); err != nil {
+			ui.Error(i18n.T("secrets.rewrite_error", err))
+			return 1
+		}
+		switch {
+		case secrets.Backend() == secrets.BackendKeyring:
+			ui.Success(i18n.T("secrets.migrated.keyring"))
+		case secrets.Encrypted():
+			ui.Success(i18n.T("secrets.migrated.encrypted", cfg.TokenFile))
+		default:
+			ui.Warning(i18n.T("secrets.migrated.plain"))
+		}
+		return 0
+
+	default:
+		usage()
+		return 2
+	}
+}
+
+// readSecret reads a value from the terminal without echo, or from stdin
+// when it is not a terminal (e.g. a pipe).
+func readSecret(prompt string) ([]byte, error) {
+	var value []byte
+	var err error
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, prompt)
+		value, err = term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+	} else {
+		value, err = io.ReadAll(os.Stdin)
+		value = []byte(strings.TrimRight(string(value), "\r\n"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, errors.New(i18n.T("secrets.empty_value"))
+	}
+	return value, nil
+}
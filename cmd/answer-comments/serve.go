@@ -0,0 +1,62 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/service"
+	"answer-comments/internal/ui"
+	"answer-comments/internal/web"
+)
+
+// runServe implements the "serve" subcommand: a local web UI to review the
+// unanswered comments in the browser.
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("serve.usage"))
+		fs.PrintDefaults()
+	}
+	addr := fs.String("addr", "127.0.0.1:8080", i18n.T("serve.flag.addr"))
+	transcriptionMode := fs.Bool("transcription", false, i18n.T("serve.flag.transcription"))
+	fs.BoolVar(transcriptionMode, "t", false, i18n.T("flag.shortcut", "transcription"))
+	metricsAddr := fs.String("metrics-addr", "", i18n.T("flag.metrics_addr"))
+	fs.Parse(args)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	myApp, err := app.NewApp(ctx, *transcriptionMode)
+	if err != nil {
+		ui.Error(i18n.T("cmd.init_error", err))
+		return 1
+	}
+	defer myApp.Close()
+
+	ui.Success(i18n.T("session.authenticated", myApp.ChannelID))
+
+	startMetrics(ctx, cmp.Or(*metricsAddr, myApp.Config.MetricsAddr))
+
+	commentService := service.NewCommentService(myApp)
+	commentService.LoadMembers(ctx)
+	ui.Success(i18n.T("review.members_loaded", commentService.MemberCount()))
+
+	server, err := web.NewServer(commentService, *transcriptionMode)
+	if err != nil {
+		ui.Error(i18n.T("serve.prepare_error", err))
+		return 1
+	}
+
+	ui.Info(i18n.T("serve.listening", *addr))
+	if err := server.ListenAndServe(ctx, *addr); err != nil {
+		ui.Error(i18n.T("serve.server_error", err))
+		return 1
+	}
+	return 0
+}
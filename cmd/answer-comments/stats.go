@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/database"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/stats"
+	"answer-comments/internal/ui"
+)
+
+// runStats implements the "stats" subcommand: engagement reports over the
+// replies stored in the local history.
+func runStats(args []string) int {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, i18n.T("stats.usage"))
+		fs.PrintDefaults()
+	}
+	since := fs.String("since", "", i18n.T("flag.since"))
+	top := fs.Int("top", 10, i18n.T("stats.flag.top"))
+	format := fs.String("format", "table", i18n.T("stats.flag.format"))
+	out := fs.String("out", "", i18n.T("stats.flag.out"))
+	fs.Parse(args)
+
+	switch *format {
+	case "table", "csv", "json":
+	default:
+		ui.Error(i18n.T("stats.unknown_format", *format))
+		return 2
+	}
+
+	var from time.Time
+	if *since != "" {
+		var err error
+		from, err = time.ParseInLocation("2006-01-02", *since, time.Local)
+		if err != nil {
+			ui.Error(i18n.T("cmd.invalid_since"))
+			return 2
+		}
+	}
+
+	myApp, err := app.NewLocalApp(context.Background())
+	if err != nil {
+		ui.Error(i18n.T("cmd.init_error", err))
+		return 1
+	}
+	defer myApp.Close()
+
+	comments, err := database.ListComments(database.HistoryFilter{Since: from})
+	if err != nil {
+		ui.Error(i18n.T("cmd.history_error", err))
+		return 1
+	}
+	report := stats.Compute(comments, from, *top)
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			ui.Error(i18n.T("cmd.create_error", *out, err))
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch *format {
+	case "json":
+		err = stats.WriteJSON(w, report)
+	case "csv":
+		err = stats.WriteCSV(w, report)
+	default:
+		if len(comments) == 0 {
+			ui.Info(i18n.T("stats.none"))
+			return 0
+		}
+		if !from.IsZero() {
+			fmt.Fprintf(w, "%s\n\n", i18n.T("stats.title", from.Format(i18n.T("cmd.date_layout"))))
+		}
+		stats.PrintReport(w, report)
+	}
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+	if *out != "" {
+		ui.Success(i18n.T("stats.saved", *out))
+	}
+	return 0
+}
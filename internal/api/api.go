@@ -0,0 +1,441 @@
+// Package api exposes the comment pipeline as a versioned JSON HTTP API, for
+// dashboards and bots built on top of the tool.
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"answer-comments/internal/database"
+	"answer-comments/internal/logging"
+	"answer-comments/internal/models"
+	"answer-comments/internal/service"
+	yt "answer-comments/internal/youtube"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+var logger = logging.For(logging.API)
+
+// Server serves the /v1 API. Analyses and suggestions are cached in memory
+// by comment ID for up to cacheTTL, so a client can analyze, suggest and
+// reply in separate calls without paying for the LLM twice.
+type Server struct {
+	svc    *service.CommentService
+	token  string
+	routes []route
+
+	mu          sync.Mutex
+	analyses    *cache[models.SentimentAnalysis]
+	suggestions *cache[string]
+	replying    map[string]bool // replies being published, by comment ID
+}
+
+// NewServer creates the API server. Every endpoint except the OpenAPI
+// document requires "Authorization: Bearer <token>".
+func NewServer(svc *service.CommentService, token string) (*Server, error) {
+	if token == "" {
+		return nil, errors.New("token de acesso da API não configurado (API_TOKEN)")
+	}
+	s := &Server{
+		svc:         svc,
+		token:       token,
+		analyses:    newCache[models.SentimentAnalysis](cacheTTL, cacheEntries),
+		suggestions: newCache[string](cacheTTL, cacheEntries),
+		replying:    make(map[string]bool),
+	}
+	s.routes = s.buildRoutes()
+	return s, nil
+}
+
+// Handler returns the HTTP handler with every route of the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, rt := range s.routes {
+		h := rt.handler
+		if !rt.public {
+			h = s.authenticated(h)
+		}
+		// Each request is its own trace, named after the route
+		pattern := rt.method + " " + rt.path
+		mux.Handle(pattern, otelhttp.NewHandler(h, pattern))
+	}
+	return mux
+}
+
+// ListenAndServe serves the API on addr until ctx is cancelled.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{Addr: addr, Handler: s.Handler()}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// ── Request and response bodies ───────────────────────────────────────────────
+
+type commentResponse struct {
+	ID              string    `json:"id" doc:"ID do comentário no YouTube"`
+	VideoID         string    `json:"video_id"`
+	AuthorName      string    `json:"author_name"`
+	AuthorChannelID string    `json:"author_channel_id"`
+	Text            string    `json:"text"`
+	PublishedAt     time.Time `json:"published_at"`
+	IsMember        bool      `json:"is_member"`
+}
+
+type unansweredResponse struct {
+	Comments      []commentResponse `json:"comments"`
+	NextPageToken string            `json:"next_page_token,omitempty" doc:"Passe em page_token para buscar o próximo lote"`
+}
+
+type analysisResponse struct {
+	CommentID     string `json:"comment_id"`
+	Sentiment     string `json:"sentiment" doc:"positivo, neutro ou negativo"`
+	Score         int    `json:"score" doc:"Nota de entendimento, de 1 a 5"`
+	Theme         string `json:"theme"`
+	Language      string `json:"language" doc:"Idioma do comentário (ISO 639-1: pt, es, en...); vazio quando não identificado"`
+	ShouldSuggest bool   `json:"should_suggest" doc:"Se a análise é confiável o suficiente para sugerir resposta automaticamente"`
+}
+
+type suggestRequest struct {
+	// Optional: the body may be empty
+	Transcription bool `json:"transcription,omitempty" doc:"Usa a transcrição do vídeo como contexto"`
+}
+
+type suggestResponse struct {
+	CommentID       string `json:"comment_id"`
+	Answer          string `json:"answer"`
+	TranscriptChars int    `json:"transcript_chars" doc:"0 = não buscada, -1 = indisponível"`
+	AuthorHistory   int    `json:"author_history"`
+	PastAnswers     int    `json:"past_answers"`
+}
+
+type replyRequest struct {
+	Text string `json:"text" doc:"Texto da resposta a publicar"`
+}
+
+type replyResponse struct {
+	CommentID    string `json:"comment_id"`
+	Saved        bool   `json:"saved" doc:"Falso quando a resposta foi publicada mas não salva no histórico local"`
+	UserAnswered bool   `json:"user_answered" doc:"Verdadeiro quando o texto difere da última sugestão"`
+}
+
+type historyEntry struct {
+	CommentID    string    `json:"comment_id"`
+	VideoID      string    `json:"video_id"`
+	Author       string    `json:"author"`
+	Comment      string    `json:"comment"`
+	Sentiment    string    `json:"sentiment"`
+	Score        int       `json:"score"`
+	Theme        string    `json:"theme"`
+	Language     string    `json:"language"`
+	Response     string    `json:"response"`
+	UserAnswered bool      `json:"user_answered"`
+	CreatedAt    time.Time `json:"created_at"`
+	RespondedAt  time.Time `json:"responded_at"`
+}
+
+type historyResponse struct {
+	Entries []historyEntry `json:"entries"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// ── Handlers ──────────────────────────────────────────────────────────────────
+
+func (s *Server) handleUnanswered(w http.ResponseWriter, r *http.Request) {
+	s.svc.LoadMembers(r.Context())
+	comments, next, err := s.svc.ListUnanswered(r.Context(), r.URL.Query().Get("page_token"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := unansweredResponse{Comments: []commentResponse{}, NextPageToken: next}
+	for _, c := range comments {
+		publishedAt, _ := time.Parse(time.RFC3339, c.Snippet.PublishedAt)
+		var authorChannelID string
+		if c.Snippet.AuthorChannelId != nil {
+			authorChannelID = c.Snippet.AuthorChannelId.Value
+		}
+		resp.Comments = append(resp.Comments, commentResponse{
+			ID:              c.Id,
+			VideoID:         c.Snippet.VideoId,
+			AuthorName:      c.Snippet.AuthorDisplayName,
+			AuthorChannelID: authorChannelID,
+			Text:            c.Snippet.TextOriginal,
+			PublishedAt:     publishedAt,
+			IsMember:        s.svc.IsMember(c),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleAnalyze(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	comment, err := s.svc.GetComment(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	sentiment, err := s.svc.Analyze(r.Context(), s.svc.Prepare(r.Context(), comment))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.mu.Lock()
+	s.analyses.put(id, sentiment, s.svc.Clock.Now())
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, analysisResponse{
+		CommentID:     id,
+		Sentiment:     sentiment.Sentimento,
+		Score:         sentiment.Nota,
+		Theme:         sentiment.Tema,
+		Language:      sentiment.Idioma,
+		ShouldSuggest: s.svc.ShouldSuggest(sentiment),
+	})
+}
+
+func (s *Server) handleSuggest(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var req suggestRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	comment, err := s.svc.GetComment(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	pc := s.svc.Prepare(r.Context(), comment)
+	sentiment, err := s.analysis(r.Context(), pc)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	sug, err := s.svc.Suggest(r.Context(), pc, sentiment, req.Transcription)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.mu.Lock()
+	s.suggestions.put(id, sug.Answer, s.svc.Clock.Now())
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, suggestResponse{
+		CommentID:       id,
+		Answer:          sug.Answer,
+		TranscriptChars: sug.TranscriptLen,
+		AuthorHistory:   sug.AuthorHistory,
+		PastAnswers:     sug.PastAnswers,
+	})
+}
+
+func (s *Server) handleReply(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var req replyRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "o campo text é obrigatório"})
+		return
+	}
+	// A retried or duplicated request must not publish a second reply
+	s.mu.Lock()
+	busy := s.replying[id]
+	s.replying[id] = true
+	s.mu.Unlock()
+	if busy {
+		writeJSON(w, http.StatusConflict, errorResponse{Error: "já há uma resposta sendo publicada para este comentário"})
+		return
+	}
+	defer func() {
+		s.mu.Lock()
+		delete(s.replying, id)
+		s.mu.Unlock()
+	}()
+
+	comment, err := s.svc.GetUnansweredComment(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// The history needs the labels of the comment: reuse the cached analysis
+	sentiment, err := s.analysis(r.Context(), s.svc.Prepare(r.Context(), comment))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	suggestion, suggested := s.suggestions.get(id, s.svc.Clock.Now())
+	s.mu.Unlock()
+	userAnswered := !suggested || suggestion != text
+
+	resp := replyResponse{CommentID: id, Saved: true, UserAnswered: userAnswered}
+	mode := service.PublishAccepted
+	if userAnswered {
+		mode = service.PublishEdited
+	}
+	err = s.svc.Publish(r.Context(), comment, sentiment, text, mode)
+	if errors.Is(err, service.ErrNotSaved) {
+		logger.Error("resposta publicada mas não salva", logging.KeyCommentID, id, logging.KeyError, err)
+		resp.Saved = false
+	} else if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.mu.Lock()
+	s.analyses.delete(id)
+	s.suggestions.delete(id)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := database.HistoryFilter{
+		Author:    q.Get("author"),
+		Theme:     q.Get("theme"),
+		Sentiment: q.Get("sentiment"),
+		VideoID:   q.Get("video_id"),
+		Limit:     50,
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > 1000 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "limit deve estar entre 1 e 1000"})
+			return
+		}
+		filter.Limit = limit
+	}
+	if v := q.Get("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "since deve estar no formato RFC 3339"})
+			return
+		}
+		filter.Since = since
+	}
+
+	comments, err := database.ListComments(filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := historyResponse{Entries: []historyEntry{}}
+	for _, c := range comments {
+		resp.Entries = append(resp.Entries, historyEntry{
+			CommentID:    c.ID,
+			VideoID:      c.VideoID,
+			Author:       c.Author,
+			Comment:      c.CommentText,
+			Sentiment:    c.Sentiment,
+			Score:        c.Score,
+			Theme:        c.Theme,
+			Language:     c.Language,
+			Response:     c.Response,
+			UserAnswered: c.UserAnswered,
+			CreatedAt:    c.CreatedAt,
+			RespondedAt:  c.RespondedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.OpenAPI())
+}
+
+// analysis returns the cached analysis of a comment, running it if needed.
+func (s *Server) analysis(ctx context.Context, pc service.PendingComment) (models.SentimentAnalysis, error) {
+	s.mu.Lock()
+	sentiment, ok := s.analyses.get(pc.Comment.Id, s.svc.Clock.Now())
+	s.mu.Unlock()
+	if ok {
+		return sentiment, nil
+	}
+	sentiment, err := s.svc.Analyze(ctx, pc)
+	if err != nil {
+		return sentiment, err
+	}
+	s.mu.Lock()
+	s.analyses.put(pc.Comment.Id, sentiment, s.svc.Clock.Now())
+	s.mu.Unlock()
+	return sentiment, nil
+}
+
+// ── Helpers ───────────────────────────────────────────────────────────────────
+
+func (s *Server) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="answer-comments"`)
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "token de acesso inválido ou ausente"})
+			return
+		}
+		next(w, r)
+	}
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if r.ContentLength == 0 {
+		return true
+	}
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("JSON inválido: %v", err)})
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		logger.Error("erro ao escrever resposta da API", logging.KeyError, err)
+	}
+}
+
+// writeError maps pipeline errors to HTTP status codes.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, yt.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrAlreadyAnswered):
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrQuotaExceeded):
+		status = http.StatusTooManyRequests
+	case errors.Is(err, service.ErrLLMUnavailable):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, service.ErrNetwork):
+		status = http.StatusBadGateway
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/clock"
+	"answer-comments/internal/database"
+	"answer-comments/internal/models"
+	"answer-comments/internal/service"
+	yt "answer-comments/internal/youtube"
+)
+
+const (
+	testToken     = "token-de-teste"
+	testChannelID = "UCcanal"
+)
+
+// newTestServer returns an API server over a fake channel with one
+// unanswered comment, c1, and a fresh database.
+func newTestServer(t *testing.T) (*Server, *yt.FakeChannel) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := database.InitDB(filepath.Join(dir, "comments.db")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(database.CloseDB)
+
+	channel := yt.NewFakeChannel(testChannelID)
+	channel.AddVideo("v1", "Vídeo de teste", "Descrição", "")
+	channel.AddThread("v1", "c1", "Ana", "UCana", "Ótimo vídeo", time.Now().Add(-time.Hour))
+
+	cfg := &app.Config{Members: app.MembersConfig{Source: "csv", CSVFile: filepath.Join(dir, "members.csv"), Refresh: 24 * time.Hour}}
+	svc := &service.CommentService{App: &app.App{Config: cfg, YT: channel, ChannelID: testChannelID}, Clock: clock.Real{}}
+	s, err := NewServer(svc, testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, channel
+}
+
+// do sends a request with the test token and returns the recorded response.
+func do(t *testing.T, s *Server, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewServerRequiresToken(t *testing.T) {
+	if _, err := NewServer(nil, ""); err == nil {
+		t.Error("servidor criado sem token")
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	s, _ := newTestServer(t)
+	h := s.Handler()
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		want   int
+	}{
+		{"no token", "/v1/history", "", http.StatusUnauthorized},
+		{"wrong token", "/v1/history", "Bearer outro-token", http.StatusUnauthorized},
+		{"token prefix", "/v1/history", "Bearer token", http.StatusUnauthorized},
+		{"basic auth", "/v1/history", "Basic " + testToken, http.StatusUnauthorized},
+		{"bare token", "/v1/history", testToken, http.StatusUnauthorized},
+		{"valid token", "/v1/history", "Bearer " + testToken, http.StatusOK},
+		{"public openapi", "/v1/openapi.json", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("resposta 401 sem WWW-Authenticate")
+			}
+		})
+	}
+}
+
+func TestReply(t *testing.T) {
+	s, channel := newTestServer(t)
+	// Analyze and suggest ran earlier: reply reuses their results
+	now := time.Now()
+	s.analyses.put("c1", models.SentimentAnalysis{Sentimento: "positivo", Nota: 5, Tema: "Elogio", Idioma: "pt"}, now)
+	s.suggestions.put("c1", "Valeu, Ana!", now)
+
+	rec := do(t, s, http.MethodPost, "/v1/comments/c1/reply", `{"text": "Valeu, Ana!"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want 201: %s", rec.Code, rec.Body)
+	}
+	var resp replyResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if want := (replyResponse{CommentID: "c1", Saved: true}); resp != want {
+		t.Errorf("resposta = %+v, want %+v", resp, want)
+	}
+	if replies := channel.Replies("c1"); len(replies) != 1 || replies[0].Snippet.TextOriginal != "Valeu, Ana!" {
+		t.Errorf("respostas no canal = %d", len(replies))
+	}
+	history, err := database.ListComments(database.HistoryFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].Theme != "Elogio" || history[0].UserAnswered {
+		t.Errorf("histórico = %+v", history)
+	}
+	if _, ok := s.analyses.get("c1", now); ok {
+		t.Error("análise ainda em cache após a resposta")
+	}
+
+	// A retried request does not publish again
+	rec = do(t, s, http.MethodPost, "/v1/comments/c1/reply", `{"text": "Valeu, Ana!"}`)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("repetição: status = %d, want 409", rec.Code)
+	}
+	if n := len(channel.Replies("c1")); n != 1 {
+		t.Errorf("%d respostas no canal após a repetição, want 1", n)
+	}
+}
+
+func TestReplyErrors(t *testing.T) {
+	s, channel := newTestServer(t)
+	channel.AddThread("v1", "c2", "Bob", "UCbob", "Valeu!", time.Now().Add(-time.Hour))
+	if err := channel.AddReply("c2", "Canal", testChannelID, "Obrigado!", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{"empty text", "/v1/comments/c1/reply", `{"text": "  "}`, http.StatusBadRequest},
+		{"no body", "/v1/comments/c1/reply", "", http.StatusBadRequest},
+		{"unknown field", "/v1/comments/c1/reply", `{"texto": "Oi"}`, http.StatusBadRequest},
+		{"unknown comment", "/v1/comments/c9/reply", `{"text": "Oi"}`, http.StatusNotFound},
+		{"answered in YouTube Studio", "/v1/comments/c2/reply", `{"text": "Oi"}`, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := do(t, s, http.MethodPost, tt.path, tt.body); rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+	if n := len(channel.Replies("c1")); n != 0 {
+		t.Errorf("%d respostas publicadas em c1, want 0", n)
+	}
+	if n := len(channel.Replies("c2")); n != 1 {
+		t.Errorf("%d respostas em c2, want 1", n)
+	}
+}
+
+// TestReplyInFlight holds a claim on the comment as a concurrent request
+// would and checks that the second one is refused.
+func TestReplyInFlight(t *testing.T) {
+	s, channel := newTestServer(t)
+	s.mu.Lock()
+	s.replying["c1"] = true
+	s.mu.Unlock()
+
+	if rec := do(t, s, http.MethodPost, "/v1/comments/c1/reply", `{"text": "Oi"}`); rec.Code != http.StatusConflict {
+		t.Errorf("status = %d, want 409", rec.Code)
+	}
+	if n := len(channel.Replies("c1")); n != 0 {
+		t.Errorf("%d respostas publicadas, want 0", n)
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import "time"
+
+// Limits of the in-memory caches of analyses and suggestions. Clients
+// usually analyze, suggest and reply within minutes; entries never used again
+// (a comment answered elsewhere, a client that gave up) must not pile up in a
+// long-running process.
+const (
+	cacheTTL     = time.Hour
+	cacheEntries = 1000
+)
+
+// cache maps comment IDs to values that expire after ttl. When it is full,
+// the oldest entry makes room for the new one. It is not safe for concurrent
+// use: the Server guards it with its mutex.
+type cache[V any] struct {
+	ttl     time.Duration
+	max     int
+	entries map[string]cacheEntry[V]
+}
+
+type cacheEntry[V any] struct {
+	value V
+	added time.Time
+}
+
+func newCache[V any](ttl time.Duration, max int) *cache[V] {
+	return &cache[V]{ttl: ttl, max: max, entries: make(map[string]cacheEntry[V])}
+}
+
+func (c *cache[V]) get(key string, now time.Time) (V, bool) {
+	e, ok := c.entries[key]
+	if !ok || now.Sub(e.added) >= c.ttl {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+func (c *cache[V]) put(key string, value V, now time.Time) {
+	delete(c.entries, key)
+	var oldest string
+	for k, e := range c.entries {
+		if now.Sub(e.added) >= c.ttl {
+			delete(c.entries, k)
+		} else if oldest == "" || e.added.Before(c.entries[oldest].added) {
+			oldest = k
+		}
+	}
+	if len(c.entries) >= c.max {
+		delete(c.entries, oldest)
+	}
+	c.entries[key] = cacheEntry[V]{value: value, added: now}
+}
+
+func (c *cache[V]) delete(key string) {
+	delete(c.entries, key)
+}
+
+func (c *cache[V]) len() int {
+	return len(c.entries)
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCacheExpires(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	c := newCache[string](time.Hour, 10)
+	c.put("c1", "Valeu!", now)
+
+	if v, ok := c.get("c1", now.Add(59*time.Minute)); !ok || v != "Valeu!" {
+		t.Errorf("get antes do TTL = %q, %v", v, ok)
+	}
+	if _, ok := c.get("c1", now.Add(time.Hour)); ok {
+		t.Error("entrada vencida ainda retornada")
+	}
+	// Expired entries are dropped on the next put
+	c.put("c2", "Obrigado!", now.Add(time.Hour))
+	if n := c.len(); n != 1 {
+		t.Errorf("%d entradas, want 1", n)
+	}
+}
+
+func TestCacheEvictsOldest(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	c := newCache[int](time.Hour, 3)
+	for i := range 5 {
+		c.put(fmt.Sprintf("c%d", i), i, now.Add(time.Duration(i)*time.Second))
+	}
+	if n := c.len(); n != 3 {
+		t.Fatalf("%d entradas, want 3", n)
+	}
+	for i, want := range []bool{false, false, true, true, true} {
+		if _, ok := c.get(fmt.Sprintf("c%d", i), now.Add(5*time.Second)); ok != want {
+			t.Errorf("c%d presente = %v, want %v", i, ok, want)
+		}
+	}
+
+	// Replacing an entry does not evict another one
+	c.put("c4", 40, now.Add(6*time.Second))
+	if n := c.len(); n != 3 {
+		t.Errorf("%d entradas após substituir, want 3", n)
+	}
+}
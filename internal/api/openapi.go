@@ -0,0 +1,237 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// route describes one endpoint. The same table registers the handlers and
+// generates the OpenAPI document, so the documentation cannot drift from
+// what is served.
+type route struct {
+	method   string
+	path     string
+	summary  string
+	query    []param
+	request  any // zero value of the request body type, nil if none
+	response any // zero value of the success response type
+	status   int // success status code
+	public   bool
+	handler  http.HandlerFunc
+}
+
+type param struct {
+	name        string
+	typ         string
+	description string
+}
+
+func (s *Server) buildRoutes() []route {
+	return []route{
+		{
+			method:   http.MethodGet,
+			path:     "/v1/comments/unanswered",
+			summary:  "Lista um lote de comentários ainda não respondidos pelo canal",
+			query:    []param{{"page_token", "string", "Token do próximo lote, retornado pela chamada anterior"}},
+			response: unansweredResponse{},
+			status:   http.StatusOK,
+			handler:  s.handleUnanswered,
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/v1/comments/{id}/analyze",
+			summary:  "Classifica sentimento, nota e tema do comentário",
+			response: analysisResponse{},
+			status:   http.StatusOK,
+			handler:  s.handleAnalyze,
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/v1/comments/{id}/suggest",
+			summary:  "Gera uma sugestão de resposta para o comentário",
+			request:  suggestRequest{},
+			response: suggestResponse{},
+			status:   http.StatusOK,
+			handler:  s.handleSuggest,
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/v1/comments/{id}/reply",
+			summary:  "Publica uma resposta no YouTube e a salva no histórico",
+			request:  replyRequest{},
+			response: replyResponse{},
+			status:   http.StatusCreated,
+			handler:  s.handleReply,
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/history",
+			summary: "Lista o histórico de respostas salvas, da mais recente para a mais antiga",
+			query: []param{
+				{"author", "string", "Nome do autor"},
+				{"theme", "string", "Tema"},
+				{"sentiment", "string", "Sentimento"},
+				{"video_id", "string", "ID do vídeo"},
+				{"since", "string", "Respondidos a partir desta data (RFC 3339)"},
+				{"limit", "integer", "Máximo de itens (1-1000, padrão 50)"},
+			},
+			response: historyResponse{},
+			status:   http.StatusOK,
+			handler:  s.handleHistory,
+		},
+		{
+			method:  http.MethodGet,
+			path:    "/v1/openapi.json",
+			summary: "Este documento OpenAPI",
+			status:  http.StatusOK,
+			public:  true,
+			handler: s.handleOpenAPI,
+		},
+	}
+}
+
+var pathParam = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// OpenAPI returns the OpenAPI 3.0 document of the API, built from the route
+// table and the request/response types.
+func (s *Server) OpenAPI() map[string]any {
+	schemas := map[string]any{
+		"errorResponse": schemaOf(reflect.TypeOf(errorResponse{}), nil),
+	}
+	paths := map[string]any{}
+
+	for _, rt := range s.routes {
+		op := map[string]any{
+			"summary":     rt.summary,
+			"operationId": operationID(rt),
+		}
+		if !rt.public {
+			op["security"] = []any{map[string]any{"bearerAuth": []any{}}}
+		}
+
+		var params []any
+		for _, m := range pathParam.FindAllStringSubmatch(rt.path, -1) {
+			params = append(params, map[string]any{
+				"name": m[1], "in": "path", "required": true,
+				"schema": map[string]any{"type": "string"},
+			})
+		}
+		for _, p := range rt.query {
+			params = append(params, map[string]any{
+				"name": p.name, "in": "query", "description": p.description,
+				"schema": map[string]any{"type": p.typ},
+			})
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+
+		if rt.request != nil {
+			op["requestBody"] = map[string]any{
+				"content": map[string]any{"application/json": map[string]any{"schema": ref(rt.request, schemas)}},
+			}
+		}
+
+		success := map[string]any{"description": http.StatusText(rt.status)}
+		if rt.response != nil {
+			success["content"] = map[string]any{"application/json": map[string]any{"schema": ref(rt.response, schemas)}}
+		}
+		errResp := map[string]any{
+			"description": "Erro",
+			"content": map[string]any{"application/json": map[string]any{
+				"schema": map[string]any{"$ref": "#/components/schemas/errorResponse"},
+			}},
+		}
+		op["responses"] = map[string]any{
+			strconv.Itoa(rt.status): success,
+			"default":               errResp,
+		}
+
+		item, _ := paths[rt.path].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[rt.path] = item
+		}
+		item[strings.ToLower(rt.method)] = op
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "answer-comments API",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+func operationID(rt route) string {
+	name := strings.NewReplacer("/v1/", "", "/{id}", "", "/", "_", ".", "_").Replace(rt.path)
+	return strings.ToLower(rt.method) + "_" + name
+}
+
+// ref registers the schema of v's type and returns a $ref to it.
+func ref(v any, schemas map[string]any) map[string]any {
+	t := reflect.TypeOf(v)
+	if _, ok := schemas[t.Name()]; !ok {
+		schemas[t.Name()] = schemaOf(t, schemas)
+	}
+	return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaOf builds the JSON schema of a Go type from its json and doc tags.
+// Fields without omitempty are listed as required, so optional request
+// fields must be tagged omitempty.
+func schemaOf(t reflect.Type, schemas map[string]any) map[string]any {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.String:
+		return map[string]any{"type": "string"}
+	case t.Kind() == reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return map[string]any{"type": "number"}
+	case t.Kind() == reflect.Slice:
+		return map[string]any{"type": "array", "items": schemaOf(t.Elem(), schemas)}
+	case t.Kind() == reflect.Struct:
+		props := map[string]any{}
+		var required []string
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			prop := schemaOf(f.Type, schemas)
+			if doc := f.Tag.Get("doc"); doc != "" {
+				prop["description"] = doc
+			}
+			props[name] = prop
+			if !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+			}
+		}
+		schema := map[string]any{"type": "object", "properties": props}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return map[string]any{}
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// TestSchemaRequired checks that the document only requires the request
+// fields the handlers cannot do without.
+func TestSchemaRequired(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want []string
+	}{
+		{"suggest", suggestRequest{}, nil},
+		{"reply", replyRequest{}, []string{"text"}},
+		{"unanswered", unansweredResponse{}, []string{"comments"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := schemaOf(reflect.TypeOf(tt.v), map[string]any{})
+			got, _ := schema["required"].([]string)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("required = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
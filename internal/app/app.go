@@ -3,75 +3,85 @@ package app
 import (
 	"context"
 	"fmt"
-	"log"
+	"net/http"
 	"os"
+	"time"
 
+	"answer-comments/internal/cassette"
 	"answer-comments/internal/database"
+	"answer-comments/internal/llm"
+	"answer-comments/internal/logging"
+	"answer-comments/internal/webhook"
 	yt "answer-comments/internal/youtube"
 
-	"github.com/joho/godotenv"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 	"google.golang.org/genai"
 )
 
-type Config struct {
-	ClientSecretFile string
-	GeminiAPIKey     string
-	MembersCSVFile   string
-	DatabaseFile     string
-	TokenFile        string
-}
+var logger = logging.For(logging.App)
 
 type App struct {
-	Config       *Config
-	YTService    *youtube.Service
-	GeminiClient *genai.Client
-	ChannelID    string
+	Config    *Config
+	YTService *youtube.Service
+	YT        yt.Client // used by the service layer; wraps YTService
+	// LLM calls Gemini with the configured models and prompts; nil in local
+	// commands when GEMINI_API_KEY is not set.
+	LLM       *llm.Client
+	ChannelID string
+	// Webhooks receives the pipeline events; nil when no URL is configured.
+	Webhooks *webhook.Dispatcher
+
+	recorder *cassette.Recorder
 }
 
 func NewApp(ctx context.Context, transcriptionMode bool) (*App, error) {
-	// Load config.env file
-	if err := godotenv.Load("config.env"); err != nil {
-		log.Printf("Aviso: Arquivo .env não encontrado. Usando variáveis de ambiente do sistema.")
+	appConfig, err := LoadConfig()
+	if err != nil {
+		return nil, err
 	}
 
-	appConfig := &Config{
-		ClientSecretFile: getEnv("CLIENT_SECRET_FILE", "data/client_secret.json"),
-		GeminiAPIKey:     os.Getenv("GEMINI_API_KEY"),
-		MembersCSVFile:   getEnv("MEMBERS_CSV_FILE", "data/members.csv"),
-		DatabaseFile:     getEnv("DATABASE_FILE", "data/comments.db"),
-		TokenFile:        getEnv("TOKEN_FILE", "data/token.json"),
+	// HTTP cassette: records or replays every API call (offline tests)
+	recorder, err := newRecorder(appConfig.Cassette)
+	if err != nil {
+		return nil, err
 	}
+	replaying := recorder != nil && recorder.Mode() == cassette.ModeReplay
 
 	if appConfig.GeminiAPIKey == "" {
-		return nil, fmt.Errorf("GEMINI_API_KEY não configurada")
+		if !replaying {
+			return nil, fmt.Errorf("GEMINI_API_KEY não configurada")
+		}
+		appConfig.GeminiAPIKey = "replay"
 	}
 
 	// Initialize database
-	if err := database.InitDB(); err != nil {
+	if err := database.InitDB(appConfig.DatabaseFile); err != nil {
 		return nil, fmt.Errorf("erro ao inicializar o banco de dados: %w", err)
 	}
 
 	// YouTube Client
-	b, err := os.ReadFile(appConfig.ClientSecretFile)
-	if err != nil {
-		return nil, fmt.Errorf("não foi possível ler o arquivo %s: %w", appConfig.ClientSecretFile, err)
-	}
-
-	scopes := []string{yt.YoutubeForceSslScope, yt.YoutubeChannelMembershipsCreatorScope}
-	if transcriptionMode {
-		scopes = append(scopes, youtube.YoutubeScope)
-	}
-	oauthConfig, err := google.ConfigFromJSON(b, scopes...)
-	if err != nil {
-		return nil, fmt.Errorf("não foi possível analisar o arquivo de segredo do cliente: %w", err)
-	}
-
-	client, err := yt.GetYoutubeClient(oauthConfig)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao obter cliente do YouTube: %w", err)
+	var client *http.Client
+	if replaying {
+		// Responses come from the fixture, so no credentials are needed
+		client = recorder.Client()
+	} else {
+		oauthConfig, err := newOAuthConfig(appConfig, transcriptionMode)
+		if err != nil {
+			return nil, err
+		}
+
+		oauthCtx := ctx
+		if recorder != nil {
+			// The OAuth transport uses the context client as its base transport
+			oauthCtx = context.WithValue(ctx, oauth2.HTTPClient, recorder.Client())
+		}
+		client, err = yt.GetYoutubeClient(oauthCtx, oauthConfig, appConfig.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao obter cliente do YouTube: %w", err)
+		}
 	}
 
 	service, err := youtube.NewService(ctx, option.WithHTTPClient(client))
@@ -81,6 +91,7 @@ func NewApp(ctx context.Context, transcriptionMode bool) (*App, error) {
 
 	// Get Channel ID
 	channelResponse, err := service.Channels.List([]string{"id"}).Mine(true).Do()
+	yt.Track("channels.list", err)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao obter o ID do canal: %w", err)
 	}
@@ -90,29 +101,123 @@ func NewApp(ctx context.Context, transcriptionMode bool) (*App, error) {
 	channelID := channelResponse.Items[0].Id
 
 	// Gemini Client
+	var geminiHTTPClient *http.Client
+	if recorder != nil {
+		geminiHTTPClient = recorder.Client()
+	}
+	geminiClient, err := newGeminiClient(ctx, appConfig.GeminiAPIKey, geminiHTTPClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &App{
+		Config:    appConfig,
+		YTService: service,
+		YT:        yt.NewAPIClient(service),
+		LLM:       llm.NewClient(geminiClient, appConfig.LLM),
+		ChannelID: channelID,
+		Webhooks:  webhook.New(channelID, appConfig.Webhooks),
+		recorder:  recorder,
+	}, nil
+}
+
+// OAuthConfig loads the configuration and returns the OAuth client of the
+// application with every scope it may use, plus the path of the token file.
+// It is used by the auth command.
+func OAuthConfig() (*oauth2.Config, string, error) {
+	appConfig, err := LoadConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	oauthConfig, err := NewOAuthConfig(appConfig)
+	if err != nil {
+		return nil, "", err
+	}
+	return oauthConfig, appConfig.TokenFile, nil
+}
+
+// NewOAuthConfig returns the OAuth client described by appConfig, with every
+// scope the application may use.
+func NewOAuthConfig(appConfig *Config) (*oauth2.Config, error) {
+	return newOAuthConfig(appConfig, true)
+}
+
+func newOAuthConfig(appConfig *Config, transcriptionMode bool) (*oauth2.Config, error) {
+	b, err := os.ReadFile(appConfig.ClientSecretFile)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível ler o arquivo %s: %w", appConfig.ClientSecretFile, err)
+	}
+	oauthConfig, err := google.ConfigFromJSON(b, yt.Scopes(transcriptionMode)...)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível analisar o arquivo de segredo do cliente: %w", err)
+	}
+	return oauthConfig, nil
+}
+
+// NewLocalApp initializes only the local pieces of the application (config,
+// database and, when GEMINI_API_KEY is set, the Gemini client). It is used by
+// commands that work on the local history and never talk to YouTube.
+func NewLocalApp(ctx context.Context) (*App, error) {
+	appConfig, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := database.InitDB(appConfig.DatabaseFile); err != nil {
+		return nil, fmt.Errorf("erro ao inicializar o banco de dados: %w", err)
+	}
+
+	a := &App{Config: appConfig}
+	if appConfig.GeminiAPIKey != "" {
+		geminiClient, err := newGeminiClient(ctx, appConfig.GeminiAPIKey, nil)
+		if err != nil {
+			return nil, err
+		}
+		a.LLM = llm.NewClient(geminiClient, appConfig.LLM)
+	}
+	return a, nil
+}
+
+// newGeminiClient creates the Gemini client. httpClient may be nil to use the
+// SDK default.
+func newGeminiClient(ctx context.Context, apiKey string, httpClient *http.Client) (*genai.Client, error) {
 	geminiClient, err := genai.NewClient(ctx, &genai.ClientConfig{
-		APIKey:  appConfig.GeminiAPIKey,
-		Backend: genai.BackendGeminiAPI,
+		APIKey:     apiKey,
+		Backend:    genai.BackendGeminiAPI,
+		HTTPClient: httpClient,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar cliente Gemini: %w", err)
 	}
+	return geminiClient, nil
+}
 
-	return &App{
-		Config:       appConfig,
-		YTService:    service,
-		GeminiClient: geminiClient,
-		ChannelID:    channelID,
-	}, nil
+// newRecorder creates the HTTP cassette configured by cfg (fixture path and
+// record or replay mode). It returns nil when no cassette is configured.
+func newRecorder(cfg CassetteConfig) (*cassette.Recorder, error) {
+	if cfg.File == "" {
+		return nil, nil
+	}
+	recorder, err := cassette.New(cfg.File, cassette.Mode(cfg.Mode), nil)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir cassete HTTP: %w", err)
+	}
+	return recorder, nil
 }
 
-func (a *App) Close() {
-	database.CloseDB()
+// Cassette returns the HTTP cassette the App records to or replays from; nil
+// when none is configured.
+func (a *App) Cassette() *cassette.Recorder {
+	return a.recorder
 }
 
-func getEnv(key, fallback string) string {
-	if value, ok := os.LookupEnv(key); ok {
-		return value
+func (a *App) Close() {
+	// Deliveries are logged in the database, so wait for them before closing it
+	a.Webhooks.Close(30 * time.Second)
+	if a.recorder != nil {
+		if err := a.recorder.Save(); err != nil {
+			logger.Error("erro ao salvar cassete HTTP", logging.KeyError, err)
+		}
 	}
-	return fallback
+	database.CloseDB()
 }
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+
+	"answer-comments/internal/cassette"
+	"answer-comments/internal/costs"
+	"answer-comments/internal/secrets"
+	"answer-comments/internal/webhook"
+)
+
+// Severity of a configuration Problem.
+const (
+	SeverityError   = "erro"
+	SeverityWarning = "aviso"
+)
+
+// Problem is an issue found by CheckConfig.
+type Problem struct {
+	Severity string
+	Message  string
+}
+
+func (p Problem) String() string {
+	return p.Severity + ": " + p.Message
+}
+
+// CheckConfig reads the configuration of the active profile like LoadConfig,
+// but instead of stopping at the first error it reports every problem it
+// finds: invalid values, unreadable files, unknown models, broken prompt
+// templates and webhooks, and missing credentials. The configuration is
+// returned even when it has errors, unless it could not be read at all.
+func CheckConfig() (*Config, []Problem) {
+	cfg, err := activeConfig()
+	if err != nil {
+		return nil, []Problem{{SeverityError, err.Error()}}
+	}
+
+	var problems []Problem
+	fail := func(format string, args ...any) {
+		problems = append(problems, Problem{SeverityError, fmt.Sprintf(format, args...)})
+	}
+	warn := func(format string, args ...any) {
+		problems = append(problems, Problem{SeverityWarning, fmt.Sprintf(format, args...)})
+	}
+
+	for _, err := range cfg.validate() {
+		fail("%v", err)
+	}
+
+	if err := secrets.Setup(cfg.Secrets); err != nil {
+		fail("%v", err)
+	} else if err := resolveSecrets(reflect.ValueOf(cfg).Elem(), ""); err != nil {
+		fail("%v", err)
+	}
+	if cfg.Secrets.KeyFile != "" {
+		if info, err := os.Stat(cfg.Secrets.KeyFile); err == nil && info.Mode().Perm()&0o077 != 0 {
+			warn("secrets.key_file (SECRETS_KEY_FILE) %s pode ser lido por outros usuários (use chmod 600)", cfg.Secrets.KeyFile)
+		}
+	}
+
+	replaying := cfg.Cassette.File != "" && cassette.Mode(cfg.Cassette.Mode) == cassette.ModeReplay
+	if cfg.GeminiAPIKey == "" && !replaying {
+		fail("gemini_api_key (GEMINI_API_KEY) não configurada")
+	}
+	if _, err := newOAuthConfig(cfg, true); err != nil {
+		fail("client_secret_file (CLIENT_SECRET_FILE): %v", err)
+	}
+	if _, err := secrets.ReadFile(cfg.TokenFile); errors.Is(err, os.ErrNotExist) {
+		warn("token_file (TOKEN_FILE) %s não existe: rode \"answer-comments auth login\"", cfg.TokenFile)
+	} else if err != nil {
+		fail("token_file (TOKEN_FILE) %s: %v", cfg.TokenFile, err)
+	}
+	// LoadConfig creates data/<profile>; any other directory must exist.
+	if dir := filepath.Dir(cfg.DatabaseFile); cfg.Profile == "" || dir != filepath.Join("data", cfg.Profile) {
+		if _, err := os.Stat(dir); err != nil {
+			fail("database_file (DATABASE_FILE): diretório %s inacessível: %v", dir, err)
+		}
+	}
+	if cfg.Members.Source == "csv" {
+		if _, err := os.Stat(cfg.Members.CSVFile); err != nil {
+			warn("members.csv_file (MEMBERS_CSV_FILE) %s não encontrado: nenhum comentário será marcado como de membro", cfg.Members.CSVFile)
+		}
+	}
+
+	prices, err := costs.LoadPrices(cfg.LLM.PricesFile)
+	if err != nil {
+		fail("llm.prices_file (LLM_PRICES_FILE): %v", err)
+		prices = costs.DefaultPrices
+	}
+	for _, m := range []struct{ key, model string }{
+		{"llm.analysis_model (LLM_ANALYSIS_MODEL)", cfg.LLM.AnalysisModel},
+		{"llm.generation_model (LLM_GENERATION_MODEL)", cfg.LLM.GenerationModel},
+		{"llm.judge_model (LLM_JUDGE_MODEL)", cfg.LLM.JudgeModel},
+	} {
+		switch _, known := prices.Lookup(m.model); {
+		case m.model == "" && m.key != "llm.judge_model (LLM_JUDGE_MODEL)":
+			fail("%s não configurado", m.key)
+		case m.model != "" && !known:
+			warn("%s: modelo %q sem preço na tabela; confira o nome e o relatório de custos", m.key, m.model)
+		}
+	}
+	for _, err := range cfg.LLM.Prompts.Check() {
+		fail("%v", err)
+	}
+
+	for _, u := range cfg.Webhooks.URLs {
+		if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			fail("webhooks.urls (WEBHOOK_URLS): URL inválida %q", u)
+		}
+	}
+	for _, e := range cfg.Webhooks.Events {
+		if !slices.Contains(webhook.EventTypes, e) {
+			fail("webhooks.events (WEBHOOK_EVENTS): evento desconhecido %q (use %v)", e, webhook.EventTypes)
+		}
+	}
+	if len(cfg.Webhooks.URLs) > 0 && cfg.Webhooks.Secret == "" {
+		warn("webhooks.secret (WEBHOOK_SECRET) vazio: as entregas não serão assinadas")
+	}
+
+	return cfg, problems
+}
+
+// Redacted returns a copy of c with the fields tagged secret replaced by
+// "***", for printing.
+func (c Config) Redacted() Config {
+	redact(reflect.ValueOf(&c).Elem())
+	return c
+}
+
+func redact(v reflect.Value) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field, fv := t.Field(i), v.Field(i)
+		switch {
+		case field.Type.Kind() == reflect.Struct && field.Type != durationType:
+			redact(fv)
+		case field.Tag.Get("secret") == "true" && fv.String() != "":
+			fv.SetString("***")
+		}
+	}
+}
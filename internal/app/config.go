@@ -0,0 +1,422 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"answer-comments/internal/cassette"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/llm"
+	"answer-comments/internal/logging"
+	"answer-comments/internal/secrets"
+	"answer-comments/internal/tracing"
+	"answer-comments/internal/webhook"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the whole configuration of the application. It is read from
+// config.yaml (or CONFIG_FILE) and overridden by config.env, the profile
+// files and the process environment; each field names its YAML key and
+// environment variable in its tags. Fields tagged secret are redacted by
+// Redacted.
+type Config struct {
+	// Profile is the name of the profile the configuration came from; ""
+	// for the default.
+	Profile string `yaml:"-"`
+	// Files lists the configuration files that were read, lowest precedence
+	// first.
+	Files []string `yaml:"-"`
+
+	GeminiAPIKey     string         `yaml:"gemini_api_key" env:"GEMINI_API_KEY" secret:"true"`
+	ClientSecretFile string         `yaml:"client_secret_file" env:"CLIENT_SECRET_FILE"`
+	TokenFile        string         `yaml:"token_file" env:"TOKEN_FILE"`
+	DatabaseFile     string         `yaml:"database_file" env:"DATABASE_FILE"`
+	Members          MembersConfig  `yaml:"members"`
+	LLM              llm.Config     `yaml:"llm"`
+	Review           ReviewConfig   `yaml:"review"`
+	APIToken         string         `yaml:"api_token" env:"API_TOKEN" secret:"true"`
+	MetricsAddr      string         `yaml:"metrics_addr" env:"METRICS_ADDR"`
+	Webhooks         webhook.Config `yaml:"webhooks"`
+	// Secrets says how tokens and API keys are stored (encryption key,
+	// keyring).
+	Secrets  secrets.Options `yaml:"secrets"`
+	Cassette CassetteConfig  `yaml:"cassette"`
+	Logging  logging.Options `yaml:"logging"`
+	Tracing  tracing.Options `yaml:"tracing"`
+	// Lang is the language of the terminal messages (pt-BR or en); "" follows
+	// LC_ALL, LC_MESSAGES and LANG. --lang takes precedence.
+	Lang string `yaml:"lang" env:"ANSWER_COMMENTS_LANG"`
+	// DefaultProfile is the profile used when --profile is not given, and
+	// ProfilesDir where the profile files live. Both select files to read,
+	// so a profile file cannot set them.
+	DefaultProfile string `yaml:"profile" env:"ANSWER_COMMENTS_PROFILE"`
+	ProfilesDir    string `yaml:"profiles_dir" env:"PROFILES_DIR"`
+}
+
+// MembersConfig says where the channel members come from.
+type MembersConfig struct {
+	CSVFile string `yaml:"csv_file" env:"MEMBERS_CSV_FILE"`
+	// Source is "auto" (Members API, falling back to the CSV), "api" or
+	// "csv".
+	Source string `yaml:"source" env:"MEMBERS_SOURCE"`
+	// Refresh is how long members fetched from the API are reused.
+	Refresh time.Duration `yaml:"refresh" env:"MEMBERS_REFRESH"`
+}
+
+// ReviewConfig holds the thresholds of the review session.
+type ReviewConfig struct {
+	// SuggestMinScore is the lowest analysis score (1-5) of a non-negative
+	// comment that gets a suggested answer without asking.
+	SuggestMinScore int `yaml:"suggest_min_score" env:"REVIEW_SUGGEST_MIN_SCORE"`
+	// PublishMinScore is the lowest score of a positive comment whose answer
+	// the auto mode publishes after the countdown.
+	PublishMinScore int `yaml:"publish_min_score" env:"REVIEW_PUBLISH_MIN_SCORE"`
+	// Countdown is how long the auto mode waits before publishing or
+	// skipping a comment.
+	Countdown time.Duration `yaml:"countdown" env:"REVIEW_COUNTDOWN"`
+}
+
+// CassetteConfig configures the HTTP cassette (offline tests).
+type CassetteConfig struct {
+	File string `yaml:"file" env:"HTTP_CASSETTE"`
+	Mode string `yaml:"mode" env:"HTTP_CASSETTE_MODE"`
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		ClientSecretFile: "data/client_secret.json",
+		TokenFile:        "data/token.json",
+		DatabaseFile:     "data/comments.db",
+		Members: MembersConfig{
+			CSVFile: "data/members.csv",
+			Source:  "auto",
+			Refresh: 24 * time.Hour,
+		},
+		LLM:         llm.DefaultConfig(),
+		Review:      ReviewConfig{SuggestMinScore: 3, PublishMinScore: 4, Countdown: 3 * time.Minute},
+		Secrets:     secrets.Options{Backend: secrets.BackendFile},
+		Cassette:    CassetteConfig{Mode: string(cassette.ModeReplay)},
+		ProfilesDir: "profiles",
+	}
+}
+
+// LoadConfig reads and validates the configuration of the active profile (see
+// SetProfile and activeConfig), applies the secrets settings and resolves secret references ("enc:v1:...",
+// "keyring:<name>") in every value.
+func LoadConfig() (*Config, error) {
+	cfg, err := activeConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := errors.Join(cfg.validate()...); err != nil {
+		return nil, err
+	}
+	if len(cfg.Files) == 0 {
+		logger.Info("nenhum arquivo de configuração encontrado (config.yaml, config.env); usando variáveis de ambiente do sistema")
+	}
+	if err := secrets.Setup(cfg.Secrets); err != nil {
+		return nil, err
+	}
+	if err := resolveSecrets(reflect.ValueOf(cfg).Elem(), ""); err != nil {
+		return nil, err
+	}
+	if cfg.Profile != "" {
+		if err := os.MkdirAll(filepath.Join("data", cfg.Profile), 0o700); err != nil {
+			return nil, fmt.Errorf("erro ao criar o diretório do perfil: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// ProfileConfig returns the configuration of the named profile ("" for the
+// default) without validating or applying it. Secret references are left
+// unresolved. It is used to inspect profiles other than the active one.
+func ProfileConfig(name string) (*Config, error) {
+	return readConfig(name)
+}
+
+// ReadConfig returns the configuration of the active profile without
+// validating or applying it, like ProfileConfig. main uses it for the
+// settings of the whole process (language, logging and tracing) before the
+// command loads the configuration itself.
+func ReadConfig() (*Config, error) {
+	return activeConfig()
+}
+
+// activeConfig reads the configuration of the profile given to SetProfile
+// or, without one, of the profile named by ANSWER_COMMENTS_PROFILE in the
+// environment, config.yaml or config.env.
+func activeConfig() (*Config, error) {
+	if profile != "" {
+		return readConfig(profile)
+	}
+	cfg, err := readConfig("")
+	if err != nil || cfg.DefaultProfile == "" {
+		return cfg, err
+	}
+	if !profileName.MatchString(cfg.DefaultProfile) {
+		return nil, fmt.Errorf("ANSWER_COMMENTS_PROFILE: nome de perfil inválido %q (use letras, números, - e _)", cfg.DefaultProfile)
+	}
+	return readConfig(cfg.DefaultProfile)
+}
+
+// readConfig layers the configuration sources, lowest precedence first:
+// defaults, config.yaml (CONFIG_FILE), config.env, the profile's own data
+// paths (data/<name>/...), profiles/<name>.yaml, profiles/<name>.env and
+// the process environment. It does not validate the result.
+func readConfig(name string) (*Config, error) {
+	cfg := defaultConfig()
+
+	baseEnv, err := godotenv.Read("config.env")
+	if err != nil {
+		// Only a missing file means "no config.env"; a file that exists but
+		// cannot be read or parsed must not be silently ignored
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("erro ao ler config.env: %w", err)
+		}
+		baseEnv = nil
+	}
+	lookupBase := func(key string) (string, bool) {
+		if v, ok := os.LookupEnv(key); ok {
+			return v, true
+		}
+		v, ok := baseEnv[key]
+		return v, ok
+	}
+
+	configFile, explicit := lookupBase("CONFIG_FILE")
+	if !explicit {
+		configFile = "config.yaml"
+	}
+	if err := cfg.readYAML(configFile, explicit); err != nil {
+		return nil, err
+	}
+	if baseEnv != nil {
+		cfg.Files = append(cfg.Files, "config.env")
+		if err := applyEnv(reflect.ValueOf(cfg).Elem(), mapLookup(baseEnv)); err != nil {
+			return nil, fmt.Errorf("config.env: %w", err)
+		}
+	}
+
+	if name != "" {
+		// The profile files are found with the base configuration; the
+		// process environment overrides it here too
+		if err := applyEnv(reflect.ValueOf(cfg).Elem(), os.LookupEnv); err != nil {
+			return nil, err
+		}
+		profilesDir := cfg.ProfilesDir
+		yamlFile, envFile, err := profileFiles(profilesDir, name)
+		if err != nil {
+			return nil, err
+		}
+		// Each profile keeps its token, database and members list apart
+		// unless its files point them elsewhere (e.g. a shared database).
+		dir := filepath.Join("data", name)
+		cfg.TokenFile = filepath.Join(dir, "token.json")
+		cfg.DatabaseFile = filepath.Join(dir, "comments.db")
+		cfg.Members.CSVFile = filepath.Join(dir, "members.csv")
+
+		if yamlFile != "" {
+			if err := cfg.readYAML(yamlFile, true); err != nil {
+				return nil, err
+			}
+		}
+		if envFile != "" {
+			values, err := godotenv.Read(envFile)
+			if err != nil {
+				return nil, fmt.Errorf("erro ao ler o perfil %s: %w", envFile, err)
+			}
+			cfg.Files = append(cfg.Files, envFile)
+			if err := applyEnv(reflect.ValueOf(cfg).Elem(), mapLookup(values)); err != nil {
+				return nil, fmt.Errorf("%s: %w", envFile, err)
+			}
+		}
+		cfg.Profile = name
+		cfg.ProfilesDir = profilesDir
+	}
+
+	if err := applyEnv(reflect.ValueOf(cfg).Elem(), os.LookupEnv); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// readYAML decodes path over cfg. Unknown keys are errors, so typos do not go
+// unnoticed. A missing file is skipped unless required.
+func (c *Config) readYAML(path string, required bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !required {
+			return nil
+		}
+		return fmt.Errorf("erro ao ler %s: %w", path, err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	c.Files = append(c.Files, path)
+	return nil
+}
+
+// validate checks the values that would make the application misbehave.
+// Files, prompts and credentials are checked by Check.
+func (c *Config) validate() []error {
+	var errs []error
+	switch c.Members.Source {
+	case "auto", "api", "csv":
+	default:
+		errs = append(errs, fmt.Errorf("members.source (MEMBERS_SOURCE) inválido %q (use auto, api ou csv)", c.Members.Source))
+	}
+	if c.Members.Refresh <= 0 {
+		errs = append(errs, fmt.Errorf("members.refresh (MEMBERS_REFRESH) precisa ser positivo"))
+	}
+	for _, s := range []struct {
+		name  string
+		score int
+	}{
+		{"review.suggest_min_score (REVIEW_SUGGEST_MIN_SCORE)", c.Review.SuggestMinScore},
+		{"review.publish_min_score (REVIEW_PUBLISH_MIN_SCORE)", c.Review.PublishMinScore},
+	} {
+		if s.score < 1 || s.score > 5 {
+			errs = append(errs, fmt.Errorf("%s precisa estar entre 1 e 5, não %d", s.name, s.score))
+		}
+	}
+	if c.Review.Countdown <= 0 {
+		errs = append(errs, fmt.Errorf("review.countdown (REVIEW_COUNTDOWN) precisa ser positivo"))
+	}
+	switch cassette.Mode(c.Cassette.Mode) {
+	case cassette.ModeRecord, cassette.ModeReplay:
+	default:
+		errs = append(errs, fmt.Errorf("cassette.mode (HTTP_CASSETTE_MODE) inválido %q (use record ou replay)", c.Cassette.Mode))
+	}
+	if _, ok := i18n.Parse(c.Lang); c.Lang != "" && !ok {
+		errs = append(errs, fmt.Errorf("lang (ANSWER_COMMENTS_LANG) inválido %q (use pt-BR ou en)", c.Lang))
+	}
+	if _, _, err := logging.ParseLevel(c.Logging.Level); err != nil {
+		errs = append(errs, fmt.Errorf("logging.level (LOG_LEVEL): %w", err))
+	}
+	switch strings.ToLower(c.Logging.Format) {
+	case "", "text", "json":
+	default:
+		errs = append(errs, fmt.Errorf("logging.format (LOG_FORMAT) inválido %q (use text ou json)", c.Logging.Format))
+	}
+	switch c.Tracing.Exporter {
+	case "", "none", "otlp", "file":
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter (TRACE_EXPORTER) inválido %q (use otlp, file ou none)", c.Tracing.Exporter))
+	}
+	return errs
+}
+
+func mapLookup(m map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := m[key]
+		return v, ok
+	}
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// applyEnv sets every field of v that has an env tag and a value in lookup,
+// recursing into nested structs. Lists are comma separated.
+func applyEnv(v reflect.Value, lookup func(string) (string, bool)) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		field, fv := t.Field(i), v.Field(i)
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			if err := applyEnv(fv, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+		name := field.Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		raw, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		if err := setField(fv, raw); err != nil {
+			return fmt.Errorf("%s inválido %q: %w", name, raw, err)
+		}
+	}
+	return nil
+}
+
+func setField(fv reflect.Value, raw string) error {
+	switch {
+	case fv.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+	case fv.Kind() == reflect.String:
+		fv.SetString(raw)
+	case fv.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case fv.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(n))
+	case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+		var list []string
+		for _, s := range strings.Split(raw, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				list = append(list, s)
+			}
+		}
+		fv.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("tipo %s não suportado", fv.Type())
+	}
+	return nil
+}
+
+// resolveSecrets replaces secret references in the string fields of v.
+func resolveSecrets(v reflect.Value, path string) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		field, fv := t.Field(i), v.Field(i)
+		name := field.Tag.Get("env")
+		if name == "" {
+			name = path + field.Name
+		}
+		switch {
+		case field.Type.Kind() == reflect.Struct && field.Type != durationType:
+			if err := resolveSecrets(fv, path+field.Name+"."); err != nil {
+				return err
+			}
+		case field.Type.Kind() == reflect.String && field.IsExported():
+			if !secrets.IsReference(fv.String()) {
+				continue
+			}
+			plain, err := secrets.Value(fv.String())
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			fv.SetString(plain)
+		}
+	}
+	return nil
+}
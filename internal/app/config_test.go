@@ -0,0 +1,103 @@
+package app
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestReadConfigEnvFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T)
+		wantErr string
+	}{
+		{"missing", func(t *testing.T) {}, ""},
+		{"valid", func(t *testing.T) {
+			write(t, "config.env", "MEMBERS_SOURCE=csv\n")
+		}, ""},
+		{"unreadable", func(t *testing.T) {
+			if err := os.Mkdir("config.env", 0o700); err != nil {
+				t.Fatal(err)
+			}
+		}, "erro ao ler config.env"},
+		{"invalid value", func(t *testing.T) {
+			write(t, "config.env", "REVIEW_COUNTDOWN=soon\n")
+		}, "REVIEW_COUNTDOWN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			tt.setup(t)
+
+			_, err := readConfig("")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("readConfig: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+
+			// config check reports the same error instead of ignoring the file
+			_, problems := CheckConfig()
+			if tt.wantErr != "" && (len(problems) != 1 || !strings.Contains(problems[0].Message, tt.wantErr)) {
+				t.Errorf("CheckConfig = %v, want %q", problems, tt.wantErr)
+			}
+		})
+	}
+}
+
+func write(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfigEnvProcessSettings(t *testing.T) {
+	t.Chdir(t.TempDir())
+	write(t, "config.env", `LOG_LEVEL=warn,llm=debug
+LOG_FILE=app.log
+TRACE_EXPORTER=file
+TRACE_FILE=spans.jsonl
+OTEL_EXPORTER_OTLP_ENDPOINT=http://collector:4318
+ANSWER_COMMENTS_LANG=en
+PROFILES_DIR=perfis
+ANSWER_COMMENTS_PROFILE=canal
+`)
+	if err := os.Mkdir("perfis", 0o700); err != nil {
+		t.Fatal(err)
+	}
+	write(t, "perfis/canal.env", "LOG_LEVEL=debug\n")
+	if err := SetProfile(""); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ReadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Profile != "canal" || cfg.ProfilesDir != "perfis" {
+		t.Errorf("perfil = %q em %q, want canal em perfis", cfg.Profile, cfg.ProfilesDir)
+	}
+	if cfg.Logging.Level != "debug" || cfg.Logging.File != "app.log" {
+		t.Errorf("logging = %+v", cfg.Logging)
+	}
+	if cfg.Tracing.Exporter != "file" || cfg.Tracing.File != "spans.jsonl" || cfg.Tracing.OTLPEndpoint != "http://collector:4318" {
+		t.Errorf("tracing = %+v", cfg.Tracing)
+	}
+	if cfg.Lang != "en" {
+		t.Errorf("lang = %q, want en", cfg.Lang)
+	}
+
+	// --profile wins over ANSWER_COMMENTS_PROFILE
+	write(t, "perfis/outro.yaml", "logging:\n  level: error\n")
+	if err := SetProfile("outro"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetProfile("") })
+	if cfg, err := ReadConfig(); err != nil || cfg.Profile != "outro" || cfg.Logging.Level != "error" {
+		t.Errorf("ReadConfig com --profile = %+v, %v", cfg, err)
+	}
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// profile is the profile chosen with --profile, set by SetProfile; "" uses
+// the default profile of the configuration (ANSWER_COMMENTS_PROFILE), if any.
+var profile string
+
+var profileName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// SetProfile selects the named profile for the configuration loaded by
+// LoadConfig, NewApp, NewLocalApp and OAuthConfig. It must be called before
+// them. Whether the profile exists is checked when the configuration is read.
+func SetProfile(name string) error {
+	if name != "" && !profileName.MatchString(name) {
+		return fmt.Errorf("nome de perfil inválido %q (use letras, números, - e _)", name)
+	}
+	profile = name
+	return nil
+}
+
+// Profile returns the profile chosen with SetProfile ("" for none).
+func Profile() string {
+	return profile
+}
+
+// profileFiles returns the files of a profile in dir: <name>.yaml and
+// <name>.env, "" for the one that does not exist. At least one must exist.
+func profileFiles(dir, name string) (yamlFile, envFile string, err error) {
+	if !profileName.MatchString(name) {
+		return "", "", fmt.Errorf("nome de perfil inválido %q (use letras, números, - e _)", name)
+	}
+	base := filepath.Join(dir, name)
+	for _, f := range []struct {
+		path string
+		dst  *string
+	}{{base + ".yaml", &yamlFile}, {base + ".env", &envFile}} {
+		_, err := os.Stat(f.path)
+		switch {
+		case err == nil:
+			*f.dst = f.path
+		case !errors.Is(err, os.ErrNotExist):
+			return "", "", err
+		}
+	}
+	if yamlFile == "" && envFile == "" {
+		return "", "", fmt.Errorf("perfil %q não encontrado: crie %s.yaml ou %s.env", name, base, base)
+	}
+	return yamlFile, envFile, nil
+}
+
+// ListProfiles returns the names of the profiles in the profiles directory
+// of the default configuration (PROFILES_DIR), sorted.
+func ListProfiles() ([]string, error) {
+	cfg, err := readConfig("")
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(cfg.ProfilesDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, e := range entries {
+		name := strings.TrimSuffix(strings.TrimSuffix(e.Name(), ".env"), ".yaml")
+		if name == e.Name() || e.IsDir() || !profileName.MatchString(name) || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,270 @@
+// Package cassette records HTTP interactions with the Google APIs into
+// fixture files and replays them later, so the whole comment flow can run
+// offline and deterministically.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder talks to the network or to the fixture.
+type Mode string
+
+const (
+	// ModeRecord forwards requests to the real transport and stores them.
+	ModeRecord Mode = "record"
+	// ModeReplay answers requests from the fixture, without network access.
+	ModeReplay Mode = "replay"
+)
+
+// scrubbed replaces every secret removed from a fixture.
+const scrubbed = "[SCRUBBED]"
+
+// Headers that carry credentials and never reach the fixture file.
+var secretHeaders = []string{"Authorization", "X-Goog-Api-Key", "Cookie", "Set-Cookie"}
+
+// Query parameters that carry credentials.
+var secretParams = []string{"key", "access_token", "client_secret", "code", "refresh_token"}
+
+// Matches credential fields in JSON or form encoded bodies.
+var secretBodyFields = regexp.MustCompile(`("(?:access_token|refresh_token|id_token|client_secret)"\s*:\s*")[^"]*(")|((?:^|&)(?:access_token|refresh_token|client_secret|code)=)[^&]*`)
+
+// Interaction is one recorded request/response pair.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the recorded, scrubbed form of an HTTP request.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is the recorded, scrubbed form of an HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions.
+type Recorder struct {
+	mode     Mode
+	path     string
+	next     http.RoundTripper
+	mu       sync.Mutex
+	recorded []Interaction
+	used     []bool
+}
+
+// New creates a Recorder backed by the fixture file at path. In replay mode
+// the file must exist; in record mode it is (re)written by Save. next is the
+// transport used to reach the network while recording; nil means
+// http.DefaultTransport.
+func New(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	r := &Recorder{mode: mode, path: path, next: next}
+
+	switch mode {
+	case ModeRecord:
+	case ModeReplay:
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: não foi possível ler %s: %w", path, err)
+		}
+		if err := json.Unmarshal(b, &r.recorded); err != nil {
+			return nil, fmt.Errorf("cassette: fixture %s inválida: %w", path, err)
+		}
+		r.used = make([]bool, len(r.recorded))
+	default:
+		return nil, fmt.Errorf("cassette: modo desconhecido %q (use record ou replay)", mode)
+	}
+	return r, nil
+}
+
+// Mode returns the mode the Recorder was created with.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an *http.Client that uses the Recorder as transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recReq := Request{
+		Method: req.Method,
+		URL:    scrubURL(req.URL),
+		Body:   scrubBody(body),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recReq)
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	r.recorded = append(r.recorded, Interaction{
+		Request: recReq,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+			Body:       scrubBody(string(respBody)),
+		},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay returns the first unused interaction matching the request. Identical
+// requests are answered in the order they were recorded.
+func (r *Recorder) replay(req *http.Request, recReq Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.recorded {
+		if r.used[i] || !matches(in.Request, recReq) {
+			continue
+		}
+		r.used[i] = true
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette: nenhuma interação gravada para %s %s", recReq.Method, recReq.URL)
+}
+
+// Save writes the recorded interactions to the fixture file. It does nothing
+// in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(r.recorded, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, b, 0644)
+}
+
+// Unused returns how many recorded interactions were not replayed. A test can
+// use it to assert that the flow made every expected call.
+func (r *Recorder) Unused() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for _, u := range r.used {
+		if !u {
+			n++
+		}
+	}
+	return n
+}
+
+func matches(recorded, req Request) bool {
+	return recorded.Method == req.Method && recorded.URL == req.URL && recorded.Body == req.Body
+}
+
+func readBody(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+	b, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return string(b), nil
+}
+
+// scrubURL removes credentials from the query string and sorts the remaining
+// parameters so the URL is stable between runs.
+func scrubURL(u *url.URL) string {
+	clean := *u
+	q := clean.Query()
+	for _, p := range secretParams {
+		if q.Has(p) {
+			q.Set(p, scrubbed)
+		}
+	}
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		for _, v := range q[k] {
+			parts = append(parts, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	clean.RawQuery = strings.Join(parts, "&")
+	return clean.String()
+}
+
+func scrubHeader(h http.Header) http.Header {
+	clean := h.Clone()
+	for _, name := range secretHeaders {
+		if clean.Get(name) != "" {
+			clean.Set(name, scrubbed)
+		}
+	}
+	return clean
+}
+
+func scrubBody(body string) string {
+	return secretBodyFields.ReplaceAllStringFunc(body, func(m string) string {
+		sub := secretBodyFields.FindStringSubmatch(m)
+		if sub[1] != "" {
+			return sub[1] + scrubbed + sub[2]
+		}
+		return sub[3] + scrubbed
+	})
+}
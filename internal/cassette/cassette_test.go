@@ -0,0 +1,205 @@
+package cassette
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// server answers every request with a numbered body, so the tests can tell
+// which recorded response was replayed.
+func server(calls *int) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		*calls++
+		body := fmt.Sprintf(`{"access_token":"ya29.secret-token","n":%d}`, *calls)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Set-Cookie": {"sid=cookie-secret"}, "Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func do(t *testing.T, c *http.Client, method, url, body string) (string, error) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer header-secret")
+	resp, err := c.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b), nil
+}
+
+// record records the interactions made by fn into a new fixture and returns
+// its path.
+func record(t *testing.T, fn func(c *http.Client)) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	var calls int
+	r, err := New(path, ModeRecord, server(&calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn(r.Client())
+	if err := r.Save(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRecordScrubsSecrets(t *testing.T) {
+	path := record(t, func(c *http.Client) {
+		if _, err := do(t, c, http.MethodPost, "https://oauth2.example/token?key=api-secret&b=2&a=1",
+			"grant_type=refresh_token&refresh_token=refresh-secret&client_secret=client-secret"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixture := string(b)
+	for _, secret := range []string{"api-secret", "refresh-secret", "client-secret", "ya29.secret-token", "cookie-secret", "header-secret"} {
+		if strings.Contains(fixture, secret) {
+			t.Errorf("a fixture contém o segredo %q:\n%s", secret, fixture)
+		}
+	}
+	var got []Interaction
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("%d interações gravadas, want 1", len(got))
+	}
+	// Query parameters are sorted so the URL does not depend on the caller
+	if want := "https://oauth2.example/token?a=1&b=2&key=%5BSCRUBBED%5D"; got[0].Request.URL != want {
+		t.Errorf("URL = %s, want %s", got[0].Request.URL, want)
+	}
+	if want := "grant_type=refresh_token&refresh_token=[SCRUBBED]&client_secret=[SCRUBBED]"; got[0].Request.Body != want {
+		t.Errorf("body = %s, want %s", got[0].Request.Body, want)
+	}
+	if want := `{"access_token":"[SCRUBBED]","n":1}`; got[0].Response.Body != want {
+		t.Errorf("resposta = %s, want %s", got[0].Response.Body, want)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	path := record(t, func(c *http.Client) {
+		for _, body := range []string{"first", "first", "second"} {
+			if _, err := do(t, c, http.MethodPost, "https://api.example/v1/items?b=2&key=recorded-key&a=1", body); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	r, err := New(path, ModeReplay, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("o replay acessou a rede: %s %s", req.Method, req.URL)
+		return nil, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := r.Client()
+
+	tests := []struct {
+		name string
+		url  string
+		body string
+		want string // "" when the request is not in the fixture
+	}{
+		// Matching ignores the query order and the scrubbed values
+		{"second request first", "https://api.example/v1/items?a=1&b=2&key=other", "second", `"n":3`},
+		{"first in order", "https://api.example/v1/items?a=1&b=2&key=other", "first", `"n":1`},
+		{"identical request", "https://api.example/v1/items?key=other&b=2&a=1", "first", `"n":2`},
+		{"exhausted", "https://api.example/v1/items?key=other&b=2&a=1", "first", ""},
+		{"other body", "https://api.example/v1/items?key=other&b=2&a=1", "third", ""},
+		{"without key", "https://api.example/v1/items?b=2&a=1", "second", ""},
+		{"other path", "https://api.example/v1/other?key=other&b=2&a=1", "second", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := do(t, c, http.MethodPost, tt.url, tt.body)
+			if tt.want == "" {
+				if err == nil || !strings.Contains(err.Error(), "nenhuma interação gravada") {
+					t.Fatalf("err = %v, want nenhuma interação gravada", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if n := r.Unused(); n != 0 {
+		t.Errorf("Unused() = %d, want 0", n)
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cassette.json")
+	var calls int
+	r, err := New(path, ModeRecord, server(&calls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("New criou a fixture antes de Save: %v", err)
+	}
+	if _, err := do(t, r.Client(), http.MethodGet, "https://api.example/v1/items", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := replay.Unused(); n != 1 {
+		t.Errorf("Unused() = %d, want 1", n)
+	}
+	// Save does not touch the fixture in replay mode
+	if err := os.WriteFile(path, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := replay.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := os.ReadFile(path); string(b) != "[]" {
+		t.Errorf("Save em replay reescreveu a fixture: %s", b)
+	}
+}
+
+func TestNewReplayMissingFixture(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil); !os.IsNotExist(errors.Unwrap(err)) {
+		t.Errorf("err = %v, want arquivo inexistente", err)
+	}
+}
@@ -0,0 +1,158 @@
+// Package clock abstracts time so countdowns and schedules can be driven by a
+// fake clock in tests.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time used by the service and the UI.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker mirrors time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Real is the wall clock.
+type Real struct{}
+
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+func (Real) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (r realTicker) C() <-chan time.Time {
+	return r.t.C
+}
+
+func (r realTicker) Stop() {
+	r.t.Stop()
+}
+
+// Fake is a manually driven clock. Time only moves on Advance, which fires
+// every active ticker once per elapsed period, so a 3-minute countdown can be
+// fast-forwarded in a single call.
+type Fake struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+// NewFake creates a fake clock set to now.
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t := &fakeTicker{clock: f, period: d, next: f.now.Add(d), ch: make(chan time.Time), done: make(chan struct{})}
+	f.tickers = append(f.tickers, t)
+	f.cond.Broadcast()
+	return t
+}
+
+// Advance moves the clock forward by d, one tick period at a time. Each tick
+// is delivered synchronously, so Advance returns only after the code waiting
+// on the ticker consumed it (or stopped the ticker).
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	target := f.now.Add(d)
+	for {
+		t := f.nextTicker(target)
+		if t == nil {
+			break
+		}
+		f.now = t.next
+		t.next = t.next.Add(t.period)
+		now := f.now
+		f.mu.Unlock()
+		select {
+		case t.ch <- now:
+		case <-t.done:
+		}
+		f.mu.Lock()
+	}
+	f.now = target
+	f.mu.Unlock()
+}
+
+// BlockUntil waits until at least n tickers are active. Tests use it to know
+// that a countdown started before sending input or advancing the clock.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.tickers) < n {
+		f.cond.Wait()
+	}
+}
+
+// nextTicker returns the active ticker due first, not after target. It must
+// be called with f.mu held.
+func (f *Fake) nextTicker(target time.Time) *fakeTicker {
+	var next *fakeTicker
+	for _, t := range f.tickers {
+		if t.next.After(target) {
+			continue
+		}
+		if next == nil || t.next.Before(next.next) {
+			next = t
+		}
+	}
+	return next
+}
+
+func (f *Fake) remove(t *fakeTicker) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, other := range f.tickers {
+		if other == t {
+			f.tickers = append(f.tickers[:i], f.tickers[i+1:]...)
+			break
+		}
+	}
+	f.cond.Broadcast()
+}
+
+type fakeTicker struct {
+	clock  *Fake
+	period time.Duration
+	next   time.Time
+	ch     chan time.Time
+	once   sync.Once
+	done   chan struct{}
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.once.Do(func() {
+		close(t.done)
+		t.clock.remove(t)
+	})
+}
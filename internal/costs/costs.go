@@ -0,0 +1,207 @@
+// Package costs computes the LLM spend from the llm_calls audit log and a
+// per-model price table.
+package costs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"answer-comments/internal/database"
+	"answer-comments/internal/i18n"
+)
+
+// Price is the cost of a model in USD per million tokens. Thinking tokens
+// are billed as output.
+type Price struct {
+	Input  float64 `json:"input"`
+	Output float64 `json:"output"`
+}
+
+// PriceTable maps a model name to its price.
+type PriceTable map[string]Price
+
+// DefaultPrices are the Gemini API list prices (paid tier, prompts up to 200k
+// tokens) when this table was written. Override them with a prices file when
+// they change.
+var DefaultPrices = PriceTable{
+	"gemini-2.0-flash-lite": {Input: 0.075, Output: 0.30},
+	"gemini-2.0-flash":      {Input: 0.10, Output: 0.40},
+	"gemini-2.5-flash-lite": {Input: 0.10, Output: 0.40},
+	"gemini-2.5-flash":      {Input: 0.30, Output: 2.50},
+	"gemini-2.5-pro":        {Input: 1.25, Output: 10.00},
+}
+
+// LoadPrices returns DefaultPrices overridden by the JSON file at path
+// ({"model": {"input": 0.1, "output": 0.4}}). An empty path returns the
+// defaults.
+func LoadPrices(path string) (PriceTable, error) {
+	table := make(PriceTable, len(DefaultPrices))
+	for model, price := range DefaultPrices {
+		table[model] = price
+	}
+	if path == "" {
+		return table, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var custom PriceTable
+	if err := json.Unmarshal(b, &custom); err != nil {
+		return nil, fmt.Errorf("tabela de preços %s inválida: %w", path, err)
+	}
+	for model, price := range custom {
+		table[model] = price
+	}
+	return table, nil
+}
+
+// Lookup returns the price of model. Versioned names such as
+// "gemini-2.0-flash-001" fall back to the longest table entry they start
+// with.
+func (t PriceTable) Lookup(model string) (Price, bool) {
+	if p, ok := t[model]; ok {
+		return p, true
+	}
+	best := ""
+	for name := range t {
+		if strings.HasPrefix(model, name) && len(name) > len(best) {
+			best = name
+		}
+	}
+	if best == "" {
+		return Price{}, false
+	}
+	return t[best], true
+}
+
+// Grouping of a report.
+const (
+	ByDay      = "day"
+	ByModel    = "model"
+	ByDayModel = "day-model"
+)
+
+// Row aggregates the calls of one group.
+type Row struct {
+	Day          string  `json:"day,omitempty"` // YYYY-MM-DD, local time
+	Model        string  `json:"model,omitempty"`
+	Calls        int     `json:"calls"`
+	Errors       int     `json:"errors"`
+	PromptTokens int     `json:"prompt_tokens"`
+	OutputTokens int     `json:"output_tokens"` // candidates + thinking
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// Report is the spend of a period.
+type Report struct {
+	Rows  []Row `json:"rows"`
+	Total Row   `json:"total"`
+	// Unpriced lists the models missing from the price table; their calls
+	// count as zero cost.
+	Unpriced []string `json:"unpriced,omitempty"`
+}
+
+// Summarize groups calls by day, model or both and prices them.
+func Summarize(calls []database.LLMCall, prices PriceTable, by string) Report {
+	var rep Report
+	rows := make(map[string]*Row)
+	unpriced := make(map[string]bool)
+
+	for _, c := range calls {
+		var key Row
+		switch by {
+		case ByDay:
+			key.Day = c.CreatedAt.Local().Format("2006-01-02")
+		case ByModel:
+			key.Model = c.Model
+		default:
+			key.Day = c.CreatedAt.Local().Format("2006-01-02")
+			key.Model = c.Model
+		}
+		k := key.Day + "|" + key.Model
+		row := rows[k]
+		if row == nil {
+			row = &key
+			rows[k] = row
+		}
+
+		output := c.CandidateTokens + c.ThoughtsTokens
+		var cost float64
+		if price, ok := prices.Lookup(c.Model); ok {
+			cost = (float64(c.PromptTokens)*price.Input + float64(output)*price.Output) / 1e6
+		} else {
+			unpriced[c.Model] = true
+		}
+		for _, r := range []*Row{row, &rep.Total} {
+			r.Calls++
+			if c.Error != "" {
+				r.Errors++
+			}
+			r.PromptTokens += c.PromptTokens
+			r.OutputTokens += output
+			r.CostUSD += cost
+		}
+	}
+
+	for _, row := range rows {
+		rep.Rows = append(rep.Rows, *row)
+	}
+	sort.Slice(rep.Rows, func(i, j int) bool {
+		if rep.Rows[i].Day != rep.Rows[j].Day {
+			return rep.Rows[i].Day < rep.Rows[j].Day
+		}
+		return rep.Rows[i].Model < rep.Rows[j].Model
+	})
+	for model := range unpriced {
+		rep.Unpriced = append(rep.Unpriced, model)
+	}
+	sort.Strings(rep.Unpriced)
+	return rep
+}
+
+// PrintReport writes the report as a table.
+func PrintReport(w io.Writer, rep Report, by string) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	switch by {
+	case ByDay:
+		fmt.Fprintf(tw, "%s\t", i18n.T("costs.column.day"))
+	case ByModel:
+		fmt.Fprintf(tw, "%s\t", i18n.T("costs.column.model"))
+	default:
+		fmt.Fprintf(tw, "%s\t%s\t", i18n.T("costs.column.day"), i18n.T("costs.column.model"))
+	}
+	fmt.Fprintf(tw, "%s\t\n", i18n.T("costs.columns"))
+
+	line := func(r Row, label ...string) {
+		for _, l := range label {
+			fmt.Fprintf(tw, "%s\t", l)
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%.4f\t\n", r.Calls, r.Errors, r.PromptTokens, r.OutputTokens, r.CostUSD)
+	}
+	for _, r := range rep.Rows {
+		switch by {
+		case ByDay:
+			line(r, r.Day)
+		case ByModel:
+			line(r, r.Model)
+		default:
+			line(r, r.Day, r.Model)
+		}
+	}
+	if by == ByDay || by == ByModel {
+		line(rep.Total, i18n.T("costs.total"))
+	} else {
+		line(rep.Total, i18n.T("costs.total"), "")
+	}
+	tw.Flush()
+
+	if len(rep.Unpriced) > 0 {
+		fmt.Fprintf(w, "\n%s\n", i18n.T("costs.unpriced", strings.Join(rep.Unpriced, ", ")))
+	}
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// BackfillState is the progress of an interrupted backfill, so the next run
+// resumes from the same page
+type BackfillState struct {
+	ChannelID string
+	PageToken string // next page to fetch
+	Pages     int    // pages fetched so far
+	Imported  int    // comments imported so far
+	UpdatedAt time.Time
+}
+
+func createBackfillStateTable() error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS backfill_state (
+			channel_id TEXT PRIMARY KEY,
+			page_token TEXT NOT NULL,
+			pages INTEGER NOT NULL DEFAULT 0,
+			imported INTEGER NOT NULL DEFAULT 0,
+			updated_at DATETIME NOT NULL
+		)
+	`)
+	return err
+}
+
+// GetBackfillState returns the saved progress of the channel's backfill. ok is
+// false when there is nothing to resume.
+func GetBackfillState(channelID string) (state BackfillState, ok bool, err error) {
+	state.ChannelID = channelID
+	err = db.QueryRow(`
+		SELECT page_token, pages, imported, updated_at
+		FROM backfill_state
+		WHERE channel_id = ?
+	`, channelID).Scan(&state.PageToken, &state.Pages, &state.Imported, &state.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return state, false, nil
+	}
+	return state, err == nil, err
+}
+
+// SaveBackfillState stores the progress of a backfill
+func SaveBackfillState(state BackfillState) error {
+	if state.UpdatedAt.IsZero() {
+		state.UpdatedAt = time.Now()
+	}
+	_, err := db.Exec(`
+		INSERT OR REPLACE INTO backfill_state (channel_id, page_token, pages, imported, updated_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		state.ChannelID, state.PageToken, state.Pages, state.Imported, state.UpdatedAt,
+	)
+	return err
+}
+
+// DeleteBackfillState forgets the progress of the channel's backfill
+func DeleteBackfillState(channelID string) error {
+	_, err := db.Exec(`DELETE FROM backfill_state WHERE channel_id = ?`, channelID)
+	return err
+}
+
+// HasComment reports whether a comment is already stored
+func HasComment(id string) (bool, error) {
+	var n int
+	err := db.QueryRow(`SELECT COUNT(*) FROM comments WHERE id = ?`, id).Scan(&n)
+	return n > 0, err
+}
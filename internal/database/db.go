@@ -2,9 +2,10 @@ package database
 
 import (
 	"database/sql"
-	"os"
+	"strings"
 	"time"
 
+	"answer-comments/internal/logging"
 	"answer-comments/internal/models"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -18,25 +19,31 @@ type DBComment struct {
 	CommentText  string    // Original comment text
 	Sentiment    string    // Sentiment analysis result
 	Score        int       // Understanding score (1-5)
+	Theme        string    // Theme classified by the analysis model
+	Language     string    // ISO 639-1 code of the comment's language; empty when unknown
 	Response     string    // Response text
 	UserAnswered bool      // Whether response was edited by user
 	CreatedAt    time.Time // When the comment was posted
 	RespondedAt  time.Time // When we responded
+	VideoID      string    // YouTube video ID
+	// AuthorChannelID and IsMember are only known for replies saved after
+	// they were added to the table
+	AuthorChannelID string
+	IsMember        sql.NullBool
 }
 
 var db *sql.DB
 
+var logger = logging.For(logging.Database)
+
 // InitDB initializes the SQLite database connection and creates tables if needed
-func InitDB() error {
+func InitDB(dbPath string) error {
 	var err error
-	dbPath := os.Getenv("DATABASE_FILE")
-	if dbPath == "" {
-		dbPath = "data/comments.db"
-	}
 	db, err = sql.Open("sqlite3", dbPath)
 	if err != nil {
 		return err
 	}
+	logger.Debug("banco de dados aberto", "path", dbPath)
 
 	// Create comments table if it doesn't exist
 	_, err = db.Exec(`
@@ -51,7 +58,10 @@ func InitDB() error {
 			user_answered BOOLEAN NOT NULL DEFAULT 0,
 			created_at DATETIME NOT NULL,
 			responded_at DATETIME,
-			video_id TEXT NOT NULL
+			video_id TEXT NOT NULL,
+			author_channel_id TEXT,
+			is_member BOOLEAN,
+			language TEXT
 		)
 	`)
 	if err != nil {
@@ -65,6 +75,7 @@ func InitDB() error {
 	if err != nil {
 		// If the error indicates that the column doesn't exist, add it
 		if err.Error() == "no such column: theme" {
+			logger.Info("migrando tabela comments: adicionando coluna theme")
 			_, err = db.Exec(`
 				ALTER TABLE comments ADD COLUMN theme TEXT
 			`)
@@ -76,21 +87,69 @@ func InitDB() error {
 		}
 	}
 
-	return nil
+	if err := addColumnIfMissing("comments", "author_channel_id", "TEXT"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("comments", "is_member", "BOOLEAN"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing("comments", "language", "TEXT"); err != nil {
+		return err
+	}
+
+	if err := createWebhookDeliveriesTable(); err != nil {
+		return err
+	}
+	if err := createLLMCallsTable(); err != nil {
+		return err
+	}
+	if err := createBackfillStateTable(); err != nil {
+		return err
+	}
+	return createMembersTables()
+}
+
+// addColumnIfMissing adds a column to an existing table, for databases
+// created by older versions
+func addColumnIfMissing(table, column, definition string) error {
+	rows, err := db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	logger.Info("migrando tabela", "table", table, "column", column)
+	_, err = db.Exec(`ALTER TABLE ` + table + ` ADD COLUMN ` + column + ` ` + definition)
+	return err
 }
 
 // SaveComment stores a comment and its response in the database
-func SaveComment(comment *youtube.Comment, sentiment string, score int, theme string, response string, userAnswered bool) error {
+func SaveComment(comment *youtube.Comment, sentiment string, score int, theme string, language string, response string, userAnswered bool, isMember bool) error {
 	createdAt, err := time.Parse(time.RFC3339, comment.Snippet.PublishedAt)
 	if err != nil {
 		return err
 	}
+	var authorChannelID string
+	if comment.Snippet.AuthorChannelId != nil {
+		authorChannelID = comment.Snippet.AuthorChannelId.Value
+	}
 
 	_, err = db.Exec(`
 		INSERT INTO comments (
 			id, author, comment_text, sentiment, score, response, theme,
-			user_answered, created_at, responded_at, video_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			user_answered, created_at, responded_at, video_id, author_channel_id, is_member, language
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		comment.Id,
 		comment.Snippet.AuthorDisplayName,
 		comment.Snippet.TextOriginal,
@@ -102,6 +161,9 @@ func SaveComment(comment *youtube.Comment, sentiment string, score int, theme st
 		createdAt,
 		time.Now(),
 		comment.Snippet.VideoId,
+		authorChannelID,
+		isMember,
+		language,
 	)
 	return err
 }
@@ -183,3 +245,110 @@ func GetPreviousAnswersByContext(theme string, sentiment string, limit int) ([]s
 
 	return results, nil
 }
+
+// SampleComments returns up to limit random answered comments, used to build
+// evaluation datasets from the local history
+func SampleComments(limit int) ([]DBComment, error) {
+	rows, err := db.Query(`
+		SELECT id, author, comment_text, sentiment, score, COALESCE(theme, ''),
+		       COALESCE(response, ''), user_answered, video_id
+		FROM comments
+		WHERE response != ''
+		ORDER BY RANDOM()
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []DBComment
+	for rows.Next() {
+		var c DBComment
+		if err := rows.Scan(&c.ID, &c.Author, &c.CommentText, &c.Sentiment, &c.Score, &c.Theme,
+			&c.Response, &c.UserAnswered, &c.VideoID); err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
+
+// HistoryFilter narrows the comments returned by ListComments. Zero values
+// mean "no filter".
+type HistoryFilter struct {
+	Author    string
+	Theme     string
+	Sentiment string
+	VideoID   string
+	Since     time.Time // responded at or after
+	// Text matches comments whose text or response contains it (case
+	// insensitive for ASCII letters)
+	Text  string
+	Limit int
+}
+
+// likeEscaper escapes the LIKE wildcards of a search text
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ListComments returns the stored comments matching filter, most recently
+// answered first
+func ListComments(filter HistoryFilter) ([]DBComment, error) {
+	query := `
+		SELECT id, author, comment_text, sentiment, score, COALESCE(theme, ''),
+		       COALESCE(response, ''), user_answered, created_at, responded_at, video_id,
+		       COALESCE(author_channel_id, ''), is_member, COALESCE(language, '')
+		FROM comments
+		WHERE 1 = 1`
+	var args []any
+	if filter.Author != "" {
+		query += " AND author = ?"
+		args = append(args, filter.Author)
+	}
+	if filter.Theme != "" {
+		query += " AND theme = ?"
+		args = append(args, filter.Theme)
+	}
+	if filter.Sentiment != "" {
+		query += " AND sentiment = ?"
+		args = append(args, filter.Sentiment)
+	}
+	if filter.VideoID != "" {
+		query += " AND video_id = ?"
+		args = append(args, filter.VideoID)
+	}
+	if !filter.Since.IsZero() {
+		query += " AND responded_at >= ?"
+		args = append(args, filter.Since)
+	}
+	if filter.Text != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Text) + "%"
+		query += ` AND (comment_text LIKE ? ESCAPE '\' OR response LIKE ? ESCAPE '\')`
+		args = append(args, pattern, pattern)
+	}
+	query += " ORDER BY responded_at DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []DBComment
+	for rows.Next() {
+		var c DBComment
+		var respondedAt sql.NullTime
+		if err := rows.Scan(&c.ID, &c.Author, &c.CommentText, &c.Sentiment, &c.Score, &c.Theme,
+			&c.Response, &c.UserAnswered, &c.CreatedAt, &respondedAt, &c.VideoID,
+			&c.AuthorChannelID, &c.IsMember, &c.Language); err != nil {
+			return nil, err
+		}
+		c.RespondedAt = respondedAt.Time
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
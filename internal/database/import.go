@@ -0,0 +1,94 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ConflictPolicy tells ImportComments what to do with a comment whose ID is
+// already stored
+type ConflictPolicy string
+
+const (
+	// ConflictSkip keeps the stored comment
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictReplace overwrites the stored comment
+	ConflictReplace ConflictPolicy = "replace"
+	// ConflictNewer overwrites the stored comment only when the imported one
+	// was answered later
+	ConflictNewer ConflictPolicy = "newer"
+)
+
+// ImportResult counts what ImportComments did (or would do, in a dry run)
+type ImportResult struct {
+	Inserted int `json:"inserted"`
+	Replaced int `json:"replaced"`
+	Skipped  int `json:"skipped"`
+}
+
+// ImportComments stores comments coming from another database, resolving ID
+// conflicts with policy. Everything runs in one transaction; with dryRun the
+// transaction is rolled back and only the counts are returned.
+func ImportComments(comments []DBComment, policy ConflictPolicy, dryRun bool) (ImportResult, error) {
+	var res ImportResult
+	switch policy {
+	case ConflictSkip, ConflictReplace, ConflictNewer:
+	default:
+		return res, fmt.Errorf("política de conflito desconhecida %q", policy)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback()
+
+	for _, c := range comments {
+		var stored sql.NullTime
+		err := tx.QueryRow(`SELECT responded_at FROM comments WHERE id = ?`, c.ID).Scan(&stored)
+		exists := err == nil
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return res, err
+		}
+
+		if exists {
+			replace := policy == ConflictReplace ||
+				(policy == ConflictNewer && c.RespondedAt.After(stored.Time))
+			if !replace {
+				res.Skipped++
+				continue
+			}
+		}
+
+		if err := insertComment(tx, c); err != nil {
+			return res, fmt.Errorf("comentário %s: %w", c.ID, err)
+		}
+		if exists {
+			res.Replaced++
+		} else {
+			res.Inserted++
+		}
+	}
+
+	if dryRun {
+		return res, nil
+	}
+	return res, tx.Commit()
+}
+
+func insertComment(tx *sql.Tx, c DBComment) error {
+	var respondedAt any
+	if !c.RespondedAt.IsZero() {
+		respondedAt = c.RespondedAt
+	}
+	_, err := tx.Exec(`
+		INSERT OR REPLACE INTO comments (
+			id, author, comment_text, sentiment, score, response, theme,
+			user_answered, created_at, responded_at, video_id, author_channel_id, is_member, language
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.ID, c.Author, c.CommentText, c.Sentiment, c.Score, c.Response, c.Theme,
+		c.UserAnswered, c.CreatedAt, respondedAt, c.VideoID, c.AuthorChannelID, c.IsMember, c.Language,
+	)
+	return err
+}
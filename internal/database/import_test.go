@@ -0,0 +1,88 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestDB opens a fresh database in a temporary directory.
+func newTestDB(t *testing.T) {
+	t.Helper()
+	if err := InitDB(filepath.Join(t.TempDir(), "comments.db")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(CloseDB)
+}
+
+// responses returns the stored response of each comment, by ID.
+func responses(t *testing.T) map[string]string {
+	t.Helper()
+	comments, err := ListComments(HistoryFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := make(map[string]string)
+	for _, c := range comments {
+		m[c.ID] = c.Response
+	}
+	return m
+}
+
+func TestImportCommentsConflicts(t *testing.T) {
+	answered := time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC)
+	comment := func(id, response string, respondedAt time.Time) DBComment {
+		return DBComment{
+			ID: id, VideoID: "v1", Author: "Ana", CommentText: "Ótimo vídeo", Sentiment: "positivo", Score: 5,
+			Theme: "Elogio", Response: response, CreatedAt: answered.Add(-time.Hour), RespondedAt: respondedAt,
+		}
+	}
+	stored := comment("c1", "guardada", answered)
+
+	tests := []struct {
+		name     string
+		imported DBComment
+		policy   ConflictPolicy
+		dryRun   bool
+		want     ImportResult
+		wantC1   string
+	}{
+		{"skip keeps the stored reply", comment("c1", "importada", answered.Add(time.Hour)), ConflictSkip, false, ImportResult{Inserted: 1, Skipped: 1}, "guardada"},
+		{"replace overwrites it", comment("c1", "importada", answered.Add(-time.Hour)), ConflictReplace, false, ImportResult{Inserted: 1, Replaced: 1}, "importada"},
+		{"newer keeps a more recent stored reply", comment("c1", "importada", answered.Add(-time.Hour)), ConflictNewer, false, ImportResult{Inserted: 1, Skipped: 1}, "guardada"},
+		{"newer takes a more recent imported reply", comment("c1", "importada", answered.Add(time.Hour)), ConflictNewer, false, ImportResult{Inserted: 1, Replaced: 1}, "importada"},
+		{"newer keeps the stored reply on a tie", comment("c1", "importada", answered), ConflictNewer, false, ImportResult{Inserted: 1, Skipped: 1}, "guardada"},
+		{"dry run changes nothing", comment("c1", "importada", answered), ConflictReplace, true, ImportResult{Inserted: 1, Replaced: 1}, "guardada"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestDB(t)
+			if _, err := ImportComments([]DBComment{stored}, ConflictSkip, false); err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := ImportComments([]DBComment{tt.imported, comment("c2", "nova", answered)}, tt.policy, tt.dryRun)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res != tt.want {
+				t.Errorf("resultado = %+v, want %+v", res, tt.want)
+			}
+
+			got := responses(t)
+			if got["c1"] != tt.wantC1 {
+				t.Errorf("resposta de c1 = %q, want %q", got["c1"], tt.wantC1)
+			}
+			if _, ok := got["c2"]; ok == tt.dryRun {
+				t.Errorf("c2 gravado = %v com dryRun = %v", ok, tt.dryRun)
+			}
+		})
+	}
+}
+
+func TestImportCommentsUnknownPolicy(t *testing.T) {
+	newTestDB(t)
+	if _, err := ImportComments(nil, "merge", false); err == nil {
+		t.Error("política desconhecida aceita")
+	}
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// LLMCall is one audited call to the LLM
+type LLMCall struct {
+	ID              int64
+	CommentID       string // empty for calls not tied to a comment (e.g. eval)
+	Operation       string // analysis, generation or judge
+	Model           string
+	PromptHash      string // SHA-256 of the rendered prompt
+	Prompt          string // full prompt, only when LLM_AUDIT_PROMPTS is enabled
+	Response        string
+	PromptTokens    int
+	CandidateTokens int
+	ThoughtsTokens  int
+	Latency         time.Duration
+	Error           string
+	CreatedAt       time.Time
+}
+
+func createLLMCallsTable() error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS llm_calls (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			comment_id TEXT,
+			operation TEXT NOT NULL,
+			model TEXT NOT NULL,
+			prompt_hash TEXT NOT NULL,
+			prompt TEXT,
+			response TEXT,
+			prompt_tokens INTEGER NOT NULL DEFAULT 0,
+			candidate_tokens INTEGER NOT NULL DEFAULT 0,
+			thoughts_tokens INTEGER NOT NULL DEFAULT 0,
+			latency_ms INTEGER NOT NULL,
+			error TEXT,
+			created_at DATETIME NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_llm_calls_comment_id ON llm_calls (comment_id)`)
+	return err
+}
+
+// SaveLLMCall stores an LLM call in the audit log
+func SaveLLMCall(c LLMCall) error {
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now()
+	}
+	_, err := db.Exec(`
+		INSERT INTO llm_calls (
+			comment_id, operation, model, prompt_hash, prompt, response,
+			prompt_tokens, candidate_tokens, thoughts_tokens, latency_ms, error, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.CommentID, c.Operation, c.Model, c.PromptHash, c.Prompt, c.Response,
+		c.PromptTokens, c.CandidateTokens, c.ThoughtsTokens, c.Latency.Milliseconds(), c.Error, c.CreatedAt,
+	)
+	return err
+}
+
+// ListLLMCalls returns the audited calls made at or after since, oldest
+// first. The prompt and response texts are left out.
+func ListLLMCalls(since time.Time) ([]LLMCall, error) {
+	rows, err := db.Query(`
+		SELECT id, COALESCE(comment_id, ''), operation, model, prompt_hash,
+		       prompt_tokens, candidate_tokens, thoughts_tokens, latency_ms, COALESCE(error, ''), created_at
+		FROM llm_calls
+		WHERE created_at >= ?
+		ORDER BY created_at
+	`, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var calls []LLMCall
+	for rows.Next() {
+		var c LLMCall
+		var latencyMs int64
+		var createdAt sql.NullTime
+		if err := rows.Scan(&c.ID, &c.CommentID, &c.Operation, &c.Model, &c.PromptHash,
+			&c.PromptTokens, &c.CandidateTokens, &c.ThoughtsTokens, &latencyMs, &c.Error, &createdAt); err != nil {
+			return nil, err
+		}
+		c.Latency = time.Duration(latencyMs) * time.Millisecond
+		c.CreatedAt = createdAt.Time
+		calls = append(calls, c)
+	}
+	return calls, rows.Err()
+}
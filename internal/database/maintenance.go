@@ -0,0 +1,46 @@
+package database
+
+import (
+	"fmt"
+	"os"
+)
+
+// Tables lists the tables created by InitDB, in creation order
+var Tables = []string{"comments", "webhook_deliveries", "llm_calls", "backfill_state", "members", "member_imports"}
+
+// TableCount is the number of rows of a table
+type TableCount struct {
+	Table string
+	Rows  int
+}
+
+// CountRows returns the number of rows of each table in Tables
+func CountRows() ([]TableCount, error) {
+	counts := make([]TableCount, 0, len(Tables))
+	for _, table := range Tables {
+		var n int
+		// table comes from Tables, never from user input
+		if err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+			return nil, fmt.Errorf("%s: %w", table, err)
+		}
+		counts = append(counts, TableCount{Table: table, Rows: n})
+	}
+	return counts, nil
+}
+
+// Backup writes a consistent copy of the database to path, which must not
+// exist yet
+func Backup(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s já existe", path)
+	}
+	_, err := db.Exec("VACUUM INTO ?", path)
+	return err
+}
+
+// Vacuum rebuilds the database file, returning the space of deleted rows to
+// the file system
+func Vacuum() error {
+	_, err := db.Exec("VACUUM")
+	return err
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"answer-comments/internal/models"
+)
+
+// MemberImport records one import of the members list
+type MemberImport struct {
+	Source     string // "csv" or "api"
+	File       string
+	FileHash   string // SHA-256 of the file, to skip unchanged files
+	Members    int
+	Skipped    int    // rows without a recognizable channel
+	Error      string // set on failed attempts, which leave the members as they were
+	ImportedAt time.Time
+}
+
+func createMembersTables() error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS members (
+			channel_id TEXT PRIMARY KEY,
+			name TEXT,
+			level TEXT,
+			member_since DATETIME,
+			source TEXT NOT NULL,
+			updated_at DATETIME NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS member_imports (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			source TEXT NOT NULL,
+			file TEXT,
+			file_hash TEXT,
+			members INTEGER NOT NULL,
+			skipped INTEGER NOT NULL DEFAULT 0,
+			error TEXT,
+			imported_at DATETIME NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	return addColumnIfMissing("member_imports", "error", "TEXT")
+}
+
+// ReplaceMembers replaces the stored members with members, the complete list
+// from imp.Source, and records the import. Members missing from the new list
+// are the ones who left.
+func ReplaceMembers(members []models.Member, imp MemberImport) error {
+	if imp.ImportedAt.IsZero() {
+		imp.ImportedAt = time.Now()
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM members`); err != nil {
+		return err
+	}
+	for _, m := range members {
+		var since any
+		if !m.Since.IsZero() {
+			since = m.Since
+		}
+		_, err := tx.Exec(`
+			INSERT OR REPLACE INTO members (channel_id, name, level, member_since, source, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			m.ChannelID, m.Name, m.Level, since, imp.Source, imp.ImportedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if err := saveMemberImport(tx, imp); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SaveFailedMemberImport records an import attempt that failed (imp.Error),
+// so the next attempt can wait for the refresh interval
+func SaveFailedMemberImport(imp MemberImport) error {
+	if imp.ImportedAt.IsZero() {
+		imp.ImportedAt = time.Now()
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := saveMemberImport(tx, imp); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func saveMemberImport(tx *sql.Tx, imp MemberImport) error {
+	_, err := tx.Exec(`
+		INSERT INTO member_imports (source, file, file_hash, members, skipped, error, imported_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		imp.Source, imp.File, imp.FileHash, imp.Members, imp.Skipped, imp.Error, imp.ImportedAt,
+	)
+	return err
+}
+
+// LastMemberImport returns the most recent import attempt from source, or
+// from any source when source is empty. ok is false when there was none.
+func LastMemberImport(source string) (imp MemberImport, ok bool, err error) {
+	var file, hash, importErr sql.NullString
+	err = db.QueryRow(`
+		SELECT source, file, file_hash, members, skipped, error, imported_at
+		FROM member_imports
+		WHERE ? = '' OR source = ?
+		ORDER BY imported_at DESC, id DESC
+		LIMIT 1
+	`, source, source).Scan(&imp.Source, &file, &hash, &imp.Members, &imp.Skipped, &importErr, &imp.ImportedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return imp, false, nil
+	}
+	imp.File, imp.FileHash, imp.Error = file.String, hash.String, importErr.String
+	return imp, err == nil, err
+}
+
+// ListMembers returns every stored member
+func ListMembers() ([]models.Member, error) {
+	rows, err := db.Query(`
+		SELECT channel_id, COALESCE(name, ''), COALESCE(level, ''), member_since
+		FROM members
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []models.Member
+	for rows.Next() {
+		var m models.Member
+		var since sql.NullTime
+		if err := rows.Scan(&m.ChannelID, &m.Name, &m.Level, &since); err != nil {
+			return nil, err
+		}
+		m.Since = since.Time
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
@@ -0,0 +1,43 @@
+package database
+
+import "time"
+
+// WebhookDelivery is one attempt to deliver a webhook event
+type WebhookDelivery struct {
+	EventID    string
+	EventType  string
+	URL        string
+	Attempt    int
+	StatusCode int    // 0 when the request did not get a response
+	Error      string // network error, empty on HTTP responses
+	CreatedAt  time.Time
+}
+
+func createWebhookDeliveriesTable() error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			event_id TEXT NOT NULL,
+			event_type TEXT NOT NULL,
+			url TEXT NOT NULL,
+			attempt INTEGER NOT NULL,
+			status_code INTEGER NOT NULL DEFAULT 0,
+			error TEXT,
+			created_at DATETIME NOT NULL
+		)
+	`)
+	return err
+}
+
+// SaveWebhookDelivery stores a delivery attempt in the delivery log
+func SaveWebhookDelivery(d WebhookDelivery) error {
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now()
+	}
+	_, err := db.Exec(`
+		INSERT INTO webhook_deliveries (event_id, event_type, url, attempt, status_code, error, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		d.EventID, d.EventType, d.URL, d.Attempt, d.StatusCode, d.Error, d.CreatedAt,
+	)
+	return err
+}
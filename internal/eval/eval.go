@@ -0,0 +1,310 @@
+package eval
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"answer-comments/internal/database"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/llm"
+	"answer-comments/internal/models"
+)
+
+// Case is one labeled comment of an evaluation dataset (one JSONL line).
+type Case struct {
+	ID                string `json:"id"`
+	Comment           string `json:"comment"`
+	VideoID           string `json:"video_id,omitempty"`
+	VideoTitle        string `json:"video_title,omitempty"`
+	VideoDescription  string `json:"video_description,omitempty"`
+	IsMember          bool   `json:"is_member,omitempty"`
+	MemberLevel       string `json:"member_level,omitempty"`
+	MemberMonths      int    `json:"member_months,omitempty"`
+	ExpectedSentiment string `json:"expected_sentiment"`
+	ExpectedTheme     string `json:"expected_theme"`
+	ReferenceAnswer   string `json:"reference_answer,omitempty"`
+}
+
+// Result holds what the models produced for a single case.
+type Result struct {
+	Case               Case   `json:"case"`
+	PredictedSentiment string `json:"predicted_sentiment"`
+	PredictedTheme     string `json:"predicted_theme"`
+	PredictedNota      int    `json:"predicted_nota"`
+	Answer             string `json:"answer,omitempty"`
+	JudgeScore         int    `json:"judge_score,omitempty"` // 1-5, 0 = not judged
+	Error              string `json:"error,omitempty"`
+}
+
+// Report aggregates the results of an evaluation run.
+type Report struct {
+	Results            []Result                  `json:"results"`
+	Total              int                       `json:"total"`
+	Errors             int                       `json:"errors"`
+	SentimentAccuracy  float64                   `json:"sentiment_accuracy"`
+	ThemeAccuracy      float64                   `json:"theme_accuracy"`
+	SentimentConfusion map[string]map[string]int `json:"sentiment_confusion"`
+	ThemeConfusion     map[string]map[string]int `json:"theme_confusion"`
+	Judged             int                       `json:"judged"`
+	MeanJudgeScore     float64                   `json:"mean_judge_score"`
+	// Similarity is MeanJudgeScore normalized to 0-1.
+	Similarity float64 `json:"similarity"`
+}
+
+// LoadDataset reads a JSONL dataset, one Case per line. Blank lines are ignored.
+func LoadDataset(path string) ([]Case, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var cases []Case
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var c Case
+		if err := json.Unmarshal([]byte(text), &c); err != nil {
+			return nil, fmt.Errorf("linha %d inválida: %w", line, err)
+		}
+		if c.Comment == "" {
+			return nil, fmt.Errorf("linha %d sem comentário", line)
+		}
+		cases = append(cases, c)
+	}
+	return cases, scanner.Err()
+}
+
+// WriteDataset writes cases as JSONL to path.
+func WriteDataset(path string, cases []Case) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetEscapeHTML(false)
+	for _, c := range cases {
+		if err := enc.Encode(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SampleFromDB builds a dataset from up to n random answered comments of the
+// local history. The stored labels and response become the expected values.
+func SampleFromDB(n int) ([]Case, error) {
+	comments, err := database.SampleComments(n)
+	if err != nil {
+		return nil, err
+	}
+	cases := make([]Case, 0, len(comments))
+	for _, c := range comments {
+		cases = append(cases, Case{
+			ID:                c.ID,
+			Comment:           c.CommentText,
+			VideoID:           c.VideoID,
+			ExpectedSentiment: c.Sentiment,
+			ExpectedTheme:     c.Theme,
+			ReferenceAnswer:   c.Response,
+		})
+	}
+	return cases, nil
+}
+
+// Run evaluates every case: classification with AnalyzeComment, generation
+// with SuggestAnswer and, when the case has a reference answer, an
+// LLM-as-judge comparison. Author history and RAG context are deliberately
+// left out: the sampled comments are in the database, so the reference
+// answer would leak into the prompt. progress, when not nil, is called after
+// each case.
+func Run(ctx context.Context, cases []Case, client *llm.Client, progress func(i int, r Result)) Report {
+	var results []Result
+	for i, c := range cases {
+		r := runCase(ctx, c, client)
+		results = append(results, r)
+		if progress != nil {
+			progress(i, r)
+		}
+	}
+	return Summarize(results)
+}
+
+func runCase(ctx context.Context, c Case, client *llm.Client) Result {
+	r := Result{Case: c}
+
+	analysis, err := client.AnalyzeComment(ctx, c.Comment)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	r.PredictedSentiment = analysis.Sentimento
+	r.PredictedTheme = analysis.Tema
+	r.PredictedNota = analysis.Nota
+
+	var member *models.Member
+	if c.IsMember {
+		member = &models.Member{Level: c.MemberLevel}
+		if c.MemberMonths > 0 {
+			member.Since = time.Now().AddDate(0, -c.MemberMonths, 0)
+		}
+	}
+	answer, err := client.SuggestAnswer(ctx, llm.AnswerRequest{
+		Comment:          c.Comment,
+		Negative:         analysis.Sentimento == "negativo",
+		Language:         analysis.Idioma,
+		VideoTitle:       c.VideoTitle,
+		VideoDescription: c.VideoDescription,
+		Member:           member,
+	})
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	r.Answer = answer
+
+	if c.ReferenceAnswer == "" || answer == "" {
+		return r
+	}
+	score, err := client.JudgeAnswer(ctx, c.Comment, c.ReferenceAnswer, answer)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	r.JudgeScore = score
+	return r
+}
+
+// Summarize computes the aggregated metrics of a set of results. Cases whose
+// analysis failed count as errors and are left out of the accuracy figures.
+func Summarize(results []Result) Report {
+	rep := Report{
+		Results:            results,
+		Total:              len(results),
+		SentimentConfusion: make(map[string]map[string]int),
+		ThemeConfusion:     make(map[string]map[string]int),
+	}
+
+	classified, sentimentHits, themeHits, judgeSum := 0, 0, 0, 0
+	for _, r := range results {
+		if r.Error != "" {
+			rep.Errors++
+		}
+		if r.PredictedSentiment == "" && r.PredictedTheme == "" {
+			continue
+		}
+		classified++
+		if sameLabel(r.Case.ExpectedSentiment, r.PredictedSentiment) {
+			sentimentHits++
+		}
+		if sameLabel(r.Case.ExpectedTheme, r.PredictedTheme) {
+			themeHits++
+		}
+		increment(rep.SentimentConfusion, label(r.Case.ExpectedSentiment), label(r.PredictedSentiment))
+		increment(rep.ThemeConfusion, label(r.Case.ExpectedTheme), label(r.PredictedTheme))
+
+		if r.JudgeScore > 0 {
+			rep.Judged++
+			judgeSum += r.JudgeScore
+		}
+	}
+
+	if classified > 0 {
+		rep.SentimentAccuracy = float64(sentimentHits) / float64(classified)
+		rep.ThemeAccuracy = float64(themeHits) / float64(classified)
+	}
+	if rep.Judged > 0 {
+		rep.MeanJudgeScore = float64(judgeSum) / float64(rep.Judged)
+		rep.Similarity = (rep.MeanJudgeScore - 1) / 4
+	}
+	return rep
+}
+
+// PrintReport writes a human readable version of the report.
+func PrintReport(w io.Writer, rep Report) {
+	fmt.Fprintln(w, i18n.T("eval.report.cases", rep.Total, rep.Errors))
+	fmt.Fprintln(w, i18n.T("eval.report.sentiment", rep.SentimentAccuracy*100))
+	fmt.Fprintln(w, i18n.T("eval.report.theme", rep.ThemeAccuracy*100))
+	if rep.Judged > 0 {
+		fmt.Fprintln(w, i18n.T("eval.report.judge", rep.MeanJudgeScore, rep.Similarity*100, rep.Judged))
+	} else {
+		fmt.Fprintln(w, i18n.T("eval.report.judge.none"))
+	}
+
+	fmt.Fprintf(w, "\n%s\n", i18n.T("eval.report.confusion.sentiment"))
+	printConfusion(w, rep.SentimentConfusion)
+	fmt.Fprintf(w, "\n%s\n", i18n.T("eval.report.confusion.theme"))
+	printConfusion(w, rep.ThemeConfusion)
+}
+
+func printConfusion(w io.Writer, matrix map[string]map[string]int) {
+	labelSet := make(map[string]bool)
+	for expected, row := range matrix {
+		labelSet[expected] = true
+		for predicted := range row {
+			labelSet[predicted] = true
+		}
+	}
+	labels := make([]string, 0, len(labelSet))
+	for l := range labelSet {
+		labels = append(labels, l)
+	}
+	sort.Strings(labels)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(tw, "\t")
+	for _, l := range labels {
+		fmt.Fprintf(tw, "%s\t", l)
+	}
+	fmt.Fprintf(tw, "%s\t\n", i18n.T("eval.report.hit"))
+	for _, expected := range labels {
+		row, ok := matrix[expected]
+		if !ok {
+			continue
+		}
+		total := 0
+		fmt.Fprintf(tw, "%s\t", expected)
+		for _, predicted := range labels {
+			total += row[predicted]
+			fmt.Fprintf(tw, "%d\t", row[predicted])
+		}
+		fmt.Fprintf(tw, "%.0f%%\t\n", float64(row[expected])/float64(total)*100)
+	}
+	tw.Flush()
+}
+
+func increment(matrix map[string]map[string]int, expected, predicted string) {
+	if matrix[expected] == nil {
+		matrix[expected] = make(map[string]int)
+	}
+	matrix[expected][predicted]++
+}
+
+func sameLabel(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// label normalizes a label for the confusion matrices.
+func label(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return "(vazio)"
+	}
+	return s
+}
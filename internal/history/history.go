@@ -0,0 +1,254 @@
+// Package history reads and writes the local reply history in portable
+// formats (CSV and JSONL), to move it between machines, merge databases and
+// feed external tools.
+package history
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"answer-comments/internal/database"
+)
+
+// Formats.
+const (
+	CSV   = "csv"
+	JSONL = "jsonl"
+)
+
+// Record is one exported comment. Field names are the same in both formats.
+type Record struct {
+	ID              string     `json:"id"`
+	VideoID         string     `json:"video_id"`
+	Author          string     `json:"author"`
+	AuthorChannelID string     `json:"author_channel_id,omitempty"`
+	IsMember        *bool      `json:"is_member,omitempty"` // nil = unknown
+	CommentText     string     `json:"comment_text"`
+	Sentiment       string     `json:"sentiment"`
+	Score           int        `json:"score"`
+	Theme           string     `json:"theme"`
+	Language        string     `json:"language,omitempty"`
+	Response        string     `json:"response"`
+	UserAnswered    bool       `json:"user_answered"`
+	CreatedAt       time.Time  `json:"created_at"`
+	RespondedAt     *time.Time `json:"responded_at,omitempty"`
+}
+
+var header = []string{
+	"id", "video_id", "author", "author_channel_id", "is_member", "comment_text",
+	"sentiment", "score", "theme", "language", "response", "user_answered", "created_at", "responded_at",
+}
+
+// FromDB converts a stored comment.
+func FromDB(c database.DBComment) Record {
+	r := Record{
+		ID:              c.ID,
+		VideoID:         c.VideoID,
+		Author:          c.Author,
+		AuthorChannelID: c.AuthorChannelID,
+		CommentText:     c.CommentText,
+		Sentiment:       c.Sentiment,
+		Score:           c.Score,
+		Theme:           c.Theme,
+		Language:        c.Language,
+		Response:        c.Response,
+		UserAnswered:    c.UserAnswered,
+		CreatedAt:       c.CreatedAt.UTC(),
+	}
+	if c.IsMember.Valid {
+		r.IsMember = &c.IsMember.Bool
+	}
+	if !c.RespondedAt.IsZero() {
+		t := c.RespondedAt.UTC()
+		r.RespondedAt = &t
+	}
+	return r
+}
+
+// DB converts the record to a comment ready for database.ImportComments.
+func (r Record) DB() database.DBComment {
+	c := database.DBComment{
+		ID:              r.ID,
+		VideoID:         r.VideoID,
+		Author:          r.Author,
+		AuthorChannelID: r.AuthorChannelID,
+		CommentText:     r.CommentText,
+		Sentiment:       r.Sentiment,
+		Score:           r.Score,
+		Theme:           r.Theme,
+		Language:        r.Language,
+		Response:        r.Response,
+		UserAnswered:    r.UserAnswered,
+		CreatedAt:       r.CreatedAt,
+	}
+	if r.IsMember != nil {
+		c.IsMember = sql.NullBool{Bool: *r.IsMember, Valid: true}
+	}
+	if r.RespondedAt != nil {
+		c.RespondedAt = *r.RespondedAt
+	}
+	return c
+}
+
+// Write writes the records in format.
+func Write(w io.Writer, format string, records []Record) error {
+	switch format {
+	case JSONL:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		for _, r := range records {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case CSV:
+		cw := csv.NewWriter(w)
+		cw.Write(header)
+		for _, r := range records {
+			var isMember, respondedAt string
+			if r.IsMember != nil {
+				isMember = strconv.FormatBool(*r.IsMember)
+			}
+			if r.RespondedAt != nil {
+				respondedAt = r.RespondedAt.Format(time.RFC3339)
+			}
+			cw.Write([]string{
+				r.ID, r.VideoID, r.Author, r.AuthorChannelID, isMember, r.CommentText,
+				r.Sentiment, strconv.Itoa(r.Score), r.Theme, r.Language, r.Response,
+				strconv.FormatBool(r.UserAnswered), r.CreatedAt.Format(time.RFC3339), respondedAt,
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("formato desconhecido %q (use csv ou jsonl)", format)
+	}
+}
+
+// Read parses records written by Write. Errors name the offending line.
+func Read(r io.Reader, format string) ([]Record, error) {
+	var records []Record
+	switch format {
+	case JSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		line := 0
+		for scanner.Scan() {
+			line++
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+			var rec Record
+			if err := json.Unmarshal([]byte(text), &rec); err != nil {
+				return nil, fmt.Errorf("linha %d: %w", line, err)
+			}
+			if err := rec.validate(); err != nil {
+				return nil, fmt.Errorf("linha %d: %w", line, err)
+			}
+			records = append(records, rec)
+		}
+		return records, scanner.Err()
+	case CSV:
+		rows, err := csv.NewReader(r).ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return nil, nil
+		}
+		cols := make(map[string]int)
+		for i, name := range rows[0] {
+			cols[strings.TrimSpace(name)] = i
+		}
+		for _, name := range []string{"id", "comment_text", "created_at"} {
+			if _, ok := cols[name]; !ok {
+				return nil, fmt.Errorf("coluna %q ausente no cabeçalho", name)
+			}
+		}
+		for i, row := range rows[1:] {
+			rec, err := parseRow(row, cols)
+			if err == nil {
+				err = rec.validate()
+			}
+			if err != nil {
+				return nil, fmt.Errorf("linha %d: %w", i+2, err)
+			}
+			records = append(records, rec)
+		}
+		return records, nil
+	default:
+		return nil, fmt.Errorf("formato desconhecido %q (use csv ou jsonl)", format)
+	}
+}
+
+// parseRow converts a CSV row; columns are looked up by header name, so
+// files edited in a spreadsheet may reorder or drop optional columns.
+func parseRow(row []string, cols map[string]int) (Record, error) {
+	get := func(name string) string {
+		if i, ok := cols[name]; ok && i < len(row) {
+			return row[i]
+		}
+		return ""
+	}
+
+	rec := Record{
+		ID:              get("id"),
+		VideoID:         get("video_id"),
+		Author:          get("author"),
+		AuthorChannelID: get("author_channel_id"),
+		CommentText:     get("comment_text"),
+		Sentiment:       get("sentiment"),
+		Theme:           get("theme"),
+		Language:        get("language"),
+		Response:        get("response"),
+	}
+	var err error
+	if s := get("score"); s != "" {
+		if rec.Score, err = strconv.Atoi(s); err != nil {
+			return rec, fmt.Errorf("score inválido %q", s)
+		}
+	}
+	if s := get("user_answered"); s != "" {
+		if rec.UserAnswered, err = strconv.ParseBool(s); err != nil {
+			return rec, fmt.Errorf("user_answered inválido %q", s)
+		}
+	}
+	if s := get("is_member"); s != "" {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return rec, fmt.Errorf("is_member inválido %q", s)
+		}
+		rec.IsMember = &b
+	}
+	if rec.CreatedAt, err = time.Parse(time.RFC3339, get("created_at")); err != nil {
+		return rec, fmt.Errorf("created_at inválido %q", get("created_at"))
+	}
+	if s := get("responded_at"); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return rec, fmt.Errorf("responded_at inválido %q", s)
+		}
+		rec.RespondedAt = &t
+	}
+	return rec, nil
+}
+
+func (r Record) validate() error {
+	switch {
+	case r.ID == "":
+		return errors.New("id vazio")
+	case r.CreatedAt.IsZero():
+		return errors.New("created_at vazio")
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package history
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"answer-comments/internal/database"
+)
+
+// openDB opens a fresh database in a temporary directory; it is closed when
+// the test ends or the next one is opened.
+func openDB(t *testing.T) {
+	t.Helper()
+	database.CloseDB()
+	if err := database.InitDB(filepath.Join(t.TempDir(), "comments.db")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(database.CloseDB)
+}
+
+// stored returns every comment in the database as records, newest reply
+// first.
+func stored(t *testing.T) []Record {
+	t.Helper()
+	comments, err := database.ListComments(database.HistoryFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []Record
+	for _, c := range comments {
+		records = append(records, FromDB(c))
+	}
+	return records
+}
+
+// TestRoundTrip exports a database and imports the file into an empty one,
+// which must end up with the same comments.
+func TestRoundTrip(t *testing.T) {
+	yes, no := true, false
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	answered := created.Add(26 * time.Hour)
+	records := []Record{
+		{
+			ID: "c1", VideoID: "v1", Author: "Ana", AuthorChannelID: "UCana", IsMember: &yes,
+			CommentText: "Ótimo vídeo, \"sério\", valeu!\nSegunda linha", Sentiment: "positivo", Score: 5,
+			Theme: "Elogio", Language: "pt", Response: "Valeu, Ana!", UserAnswered: true,
+			CreatedAt: created, RespondedAt: &answered,
+		},
+		{
+			ID: "c2", VideoID: "v1", Author: "Bob", AuthorChannelID: "UCbob", IsMember: &no,
+			CommentText: "Didn't work, sorry", Sentiment: "negativo", Score: 2, Theme: "Crítica",
+			Language: "en", Response: "Which step failed?", CreatedAt: created, RespondedAt: &created,
+		},
+		// Saved before the channel and language columns existed, never
+		// answered by the tool
+		{ID: "c3", VideoID: "v2", Author: "Carlos", CommentText: "Primeiro!", Sentiment: "neutro", Score: 3, CreatedAt: created},
+	}
+
+	for _, format := range []string{CSV, JSONL} {
+		t.Run(format, func(t *testing.T) {
+			openDB(t)
+			var comments []database.DBComment
+			for _, r := range records {
+				comments = append(comments, r.DB())
+			}
+			if _, err := database.ImportComments(comments, database.ConflictSkip, false); err != nil {
+				t.Fatal(err)
+			}
+			exported := stored(t)
+			if len(exported) != len(records) {
+				t.Fatalf("%d comentários gravados, want %d", len(exported), len(records))
+			}
+
+			var buf bytes.Buffer
+			if err := Write(&buf, format, exported); err != nil {
+				t.Fatal(err)
+			}
+			read, err := Read(&buf, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(read, exported) {
+				t.Fatalf("lido = %+v\nwant %+v", read, exported)
+			}
+
+			openDB(t)
+			comments = comments[:0]
+			for _, r := range read {
+				comments = append(comments, r.DB())
+			}
+			res, err := database.ImportComments(comments, database.ConflictSkip, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Inserted != len(records) {
+				t.Errorf("%d inseridos, want %d", res.Inserted, len(records))
+			}
+			if got := stored(t); !reflect.DeepEqual(got, exported) {
+				t.Errorf("importado = %+v\nwant %+v", got, exported)
+			}
+		})
+	}
+}
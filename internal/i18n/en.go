@@ -0,0 +1,539 @@
+package i18n
+
+// en is the English catalog. Missing keys fall back to pt-BR.
+var en = map[string]string{
+	"key.publish": "P",
+	"key.edit":    "E",
+	"key.skip":    "S",
+	"key.quit":    "Q",
+
+	"sentiment.positivo": "positive",
+	"sentiment.negativo": "negative",
+	"sentiment.neutro":   "neutral",
+
+	"ui.member_badge":               "MEMBER",
+	"ui.comment":                    "Comment:",
+	"ui.action":                     "Action:",
+	"ui.action.publish":             "Publish",
+	"ui.action.edit":                "Edit",
+	"ui.action.skip":                "Skip",
+	"ui.action.quit":                "Quit",
+	"ui.edit_prompt":                "Type your reply:",
+	"ui.searching":                  "Looking for new unanswered comments...",
+	"ui.context.unavailable":        "unavailable",
+	"ui.context.interactions.one":   "%d interaction",
+	"ui.context.interactions.other": "%d interactions",
+	"ui.context.similar.one":        "%d similar",
+	"ui.context.similar.other":      "%d similar",
+	"ui.countdown":                  "%s %d:%02d... [Enter to edit]",
+
+	"review.members_loaded":       "Loaded %d channel members.",
+	"review.press_enter_to_start": "Press Enter to start checking comments...",
+	"review.all_pages_checked":    "All comment pages have been checked.",
+	"review.no_more_comments":     "No more unanswered comments in any available page.",
+	"review.no_more_in_batch":     "No more unanswered comments in this batch.",
+	"review.next_batch":           "Press Enter to fetch the next batch, or type %s to quit: ",
+	"review.header":               "New unanswered comment found",
+	"review.date_layout":          "Jan 2, 2006 at 15:04",
+	"review.section.analysis":     "Comment analysis",
+	"review.section.context":      "Context",
+	"review.section.suggestion":   "Suggested reply",
+	"review.no_suggestion":        "Could not generate a suggested reply.",
+	"review.auto_publish":         "The suggested reply will be published automatically.",
+	"review.not_suggested":        "Chose not to generate an automatic reply.",
+	"review.countdown.publish":    "Publishing in",
+	"review.countdown.skip":       "Skipping comment in",
+	"review.countdown.sentiment":  "Sentiment %s —",
+	"review.countdown.score":      "Score %d (minimum %d) —",
+	"review.empty_answer":         "Empty reply — comment ignored.",
+	"review.not_published":        "Reply not published.",
+	"review.published_not_saved":  "Reply published, but saving it to the local history failed!",
+	"review.published":            "Reply published and saved!",
+
+	"members.csv_not_found":     "Members file '%s' not found.",
+	"members.csv_stale":         "Members file is out of date (older than 10 days).",
+	"members.csv_skipped.one":   "Ignored %d line of the members file without a recognizable channel.",
+	"members.csv_skipped.other": "Ignored %d lines of the members file without a recognizable channel.",
+
+	"banner.manual":             "Manual Mode On",
+	"banner.manual.desc":        "Every reply must be written by hand.",
+	"banner.auto":               "Auto-Reply Mode On",
+	"banner.auto.desc":          "High-confidence replies will be published automatically.",
+	"banner.transcription":      "Transcription Mode On",
+	"banner.transcription.desc": "Video transcripts will be used as context for the LLM.",
+	"banner.debug":              "Debug Mode On",
+	"banner.debug.desc":         "A debug file will be saved.",
+
+	"session.authenticated":    "Authenticated! Your channel ID: %s",
+	"session.profile":          "Profile: %s",
+	"metrics.available":        "Metrics available at http://%s/metrics",
+	"watch.interval_too_short": "-interval must be at least 1m.",
+	"watch.next":               "Next check at %s (Ctrl+C to quit).",
+
+	"flag.shortcut":      "Shorthand for --%s",
+	"flag.manual":        "Manual mode: skip the LLM suggestion and write every reply by hand",
+	"flag.auto":          "Auto-reply mode: every high-confidence suggested reply is published without confirmation",
+	"flag.transcription": "Transcription mode: use the video's automatic transcript as context for the LLM (except for Greeting/Thanks comments)",
+	"flag.debug":         "Write debug logs to debug.log (or the path set with --debug-log)",
+	"flag.debug_log":     "Path of the debug log file (requires --debug)",
+	"flag.log_level":     "Log level, optionally per subsystem: info,llm=debug,youtube=warn (default: LOG_LEVEL or info)",
+	"flag.log_format":    "Log format: text or json (default: LOG_FORMAT or text)",
+	"flag.log_file":      "Log file; the terminal then shows only warnings and errors (default: LOG_FILE)",
+	"flag.metrics_addr":  "Serve Prometheus metrics at http://<address>/metrics (default: metrics_addr from the configuration; empty disables)",
+	"flag.interval":      "Interval between checks (minimum 1m)",
+
+	"cmd.unknown":       "Unknown command %q.",
+	"cmd.review.args":   "[options]",
+	"cmd.review":        "Review unanswered comments in the terminal (default without a command)",
+	"cmd.run.args":      "[options]",
+	"cmd.run":           "One pass without a reviewer: publish confident replies and skip the rest",
+	"cmd.watch.args":    "[options]",
+	"cmd.watch":         "Repeat run at an interval, until Ctrl+C",
+	"cmd.serve.args":    "[options]",
+	"cmd.serve":         "Web review interface at http://127.0.0.1:8080/",
+	"cmd.api.args":      "[options]",
+	"cmd.api":           "JSON API at http://127.0.0.1:8081/v1",
+	"cmd.stats.args":    "[options]",
+	"cmd.stats":         "Engagement reports from the local history",
+	"cmd.search.args":   "[options] [text]",
+	"cmd.search":        "Search comments and replies in the local history",
+	"cmd.export.args":   "[options]",
+	"cmd.export":        "Export the history as CSV or JSONL",
+	"cmd.import.args":   "[options] file",
+	"cmd.import":        "Import a file produced by export",
+	"cmd.backfill.args": "[options]",
+	"cmd.backfill":      "Import replies already published on the channel",
+	"cmd.db.args":       "info|backup|vacuum",
+	"cmd.db":            "Local database maintenance",
+	"cmd.costs.args":    "[options]",
+	"cmd.costs":         "LLM spending per day and model",
+	"cmd.eval.args":     "[options] dataset.jsonl",
+	"cmd.eval":          "Evaluate prompts and models against a labeled dataset",
+	"cmd.auth.args":     "login|logout|status",
+	"cmd.auth":          "YouTube channel authorization",
+	"cmd.profiles.args": "[options]",
+	"cmd.profiles":      "List the profiles and the authorization status of each",
+	"cmd.secrets.args":  "keygen|encrypt|set|migrate",
+	"cmd.secrets":       "Encrypted secrets",
+	"cmd.config.args":   "check|show",
+	"cmd.config":        "Validate the configuration and show the effective values",
+
+	"usage.intro": `YouTube Answer Comments - Smart assistant for replying to comments
+
+This tool watches the unanswered comments of your YouTube channel and
+suggests replies using AI (Gemini), taking into account the video's context,
+the history of interactions and similar past replies.
+
+USAGE:
+  answer-comments [--lang language] [--profile name] [command] [options]
+
+COMMANDS:
+`,
+	"usage.outro": `
+Without a command, review runs: "answer-comments -a" is the same as "answer-comments review -a".
+Use "answer-comments help command" or "answer-comments command -h" for the options of each command.
+
+--profile name (or ANSWER_COMMENTS_PROFILE) applies to every command and uses
+profiles/name.yaml or .env: token, database, members and prompts of each channel.
+
+--lang pt-BR|en (or ANSWER_COMMENTS_LANG, LC_ALL, LC_MESSAGES, LANG) selects the
+language of the terminal. The keys of the action menu only change with --lang
+or ANSWER_COMMENTS_LANG.
+
+EXAMPLES:
+  answer-comments              # Terminal review with AI suggestions
+  answer-comments -a -t        # Review with automatic publishing and transcripts
+  answer-comments run          # Pass without a reviewer (cron, scheduler)
+  answer-comments watch -interval 30m # Check for new comments every 30 minutes
+  answer-comments search -author @someone price # Search the history
+  answer-comments stats -since 2026-01-01 -format csv -out stats.csv # Engagement reports
+  answer-comments db backup copy.db # Consistent copy of the database
+  answer-comments --profile main-channel -a # Review another channel
+  answer-comments --lang pt-BR # Review with the interface in Portuguese
+  answer-comments config check # Validate the configuration
+`,
+	"usage.exit_codes": `EXIT CODES:
+  0  Every comment reviewed or session ended by the user (%s)
+  1  Startup or processing error
+  3  Network error
+  4  YouTube API quota exhausted
+  5  LLM (Gemini) unavailable
+`,
+
+	"review.usage": `Review the channel's unanswered comments in the terminal, one at a time, with
+AI-suggested replies. It is the default command when none is given.
+
+USAGE:
+  answer-comments [review] [options]
+
+OPTIONS:
+`,
+	"review.usage.examples": `
+REQUIREMENTS:
+  - client_secret.json: OAuth2 credentials of the YouTube API
+  - GEMINI_API_KEY: Environment variable with the Gemini API key
+  - members.csv (optional): List of channel members
+
+EXAMPLES:
+  answer-comments              # Default mode with AI suggestions
+  answer-comments -d           # Debug mode (writes a debug file)
+  answer-comments --log-level info,llm=debug --log-format json # Structured logs
+  answer-comments -m           # Manual mode (no suggestions)
+  answer-comments -a           # Automatic mode (publishes without confirmation)
+  answer-comments -t           # Use video transcripts as context
+  answer-comments -a -t        # Automatic mode with transcripts
+  answer-comments -a --metrics-addr 127.0.0.1:9090 # Serve /metrics for Prometheus
+`,
+	"run.usage": `Check every unanswered comment once, without a reviewer: publish the replies
+that auto-reply mode would publish (review.publish_min_score) and skip the
+rest, with no prompts or countdowns. Meant for cron and schedulers.
+
+USAGE:
+  answer-comments run [options]
+
+OPTIONS:
+`,
+	"watch.usage": `Repeat "run" at an interval, until Ctrl+C: new comments are answered (or
+skipped) without a reviewer. Comments already skipped are not analyzed again
+while the process is running. Network, quota or LLM errors stop only the
+current pass; the next one is tried at the following interval.
+
+USAGE:
+  answer-comments watch [options]
+
+OPTIONS:
+`,
+
+	"cmd.init_error":         "Error initializing the application: %v",
+	"cmd.option_needs_value": "--%s requires a value",
+	"cmd.invalid_since":      "Invalid date in -since (use YYYY-MM-DD).",
+	"cmd.history_error":      "Error reading the history: %v",
+	"cmd.create_error":       "Could not create %s: %v",
+	"cmd.no_token":           "No token saved in %s.",
+	"cmd.gemini_key_missing": "GEMINI_API_KEY is not set",
+	"cmd.date_layout":        "Jan 2, 2006",
+	"flag.since":             "Count replies from this date on (YYYY-MM-DD; default: the whole history)",
+	"flag.theme":             "Only comments with this theme",
+
+	"api.usage": `Start the JSON HTTP API (/v1) over the comment pipeline.
+
+USAGE:
+  API_TOKEN=... answer-comments api [options]
+
+Every route requires the "Authorization: Bearer $API_TOKEN" header,
+except GET /v1/openapi.json, which describes the API.
+
+OPTIONS:
+`,
+	"api.flag.addr":    "Listen address of the API",
+	"api.listening":    "API available at http://%s/v1 (document at /v1/openapi.json)",
+	"api.server_error": "API server error: %v",
+
+	"serve.usage": `Start the local web UI to review comments.
+
+USAGE:
+  answer-comments serve [options]
+
+OPTIONS:
+`,
+	"serve.flag.addr":          "Listen address (only localhost is accepted)",
+	"serve.flag.transcription": "Use the automatic transcript of the video as context for the LLM",
+	"serve.prepare_error":      "Error preparing the web UI: %v",
+	"serve.listening":          "Review UI available at http://%s/ (Ctrl+C to stop)",
+	"serve.server_error":       "Web server error: %v",
+
+	"auth.usage": `Manage the authorization to access the YouTube channel.
+
+USAGE:
+  answer-comments auth login   # authorize in the browser and save the token
+  answer-comments auth logout  # revoke the token and delete the file
+  answer-comments auth status  # show whether the saved token is valid
+`,
+	"auth.login_done":        "Authorization complete.",
+	"auth.logout_done":       "Token revoked and removed.",
+	"auth.not_authenticated": `Not authenticated: no token in %s. Run "answer-comments auth login".`,
+	"auth.unreadable":        "Token in %s is unreadable: %v",
+	"auth.file":              "File",
+	"auth.no_refresh_token":  "The token has no refresh token; you will have to authorize again when it expires.",
+	"auth.revoked":           "The authorization was revoked or has expired.",
+	"auth.validate_error":    "Could not validate the token: %v",
+	"auth.login_again":       `Run "answer-comments auth login" to authorize again.`,
+	"auth.valid":             "Authenticated; the token is valid.",
+	"auth.open_browser":      "Opening the browser to authorize the application. If it does not open, go to:\n%s",
+	"auth.saving_token":      "Saving the credentials file to: %s",
+	"auth.reauthorize":       "The saved authorization was revoked or has expired; the application must be authorized again.",
+	"auth.callback_failed":   "Authorization failed: %v",
+	"auth.callback_done":     "Authorization complete. You can close this tab and go back to the terminal.",
+
+	"profiles.usage": `List the profiles (one per channel) and the authorization status of each.
+
+USAGE:
+  answer-comments profiles [options]
+
+Each profile is a file profiles/<name>.yaml or .env (directory in PROFILES_DIR)
+selected with --profile <name> in any command.
+
+OPTIONS:
+`,
+	"profiles.flag.offline":                 "Do not validate the tokens with Google; only show whether they exist",
+	"profiles.list_error":                   "Error listing the profiles: %v",
+	"profiles.none":                         "No profiles in profiles/; using only the default configuration.",
+	"profiles.header":                       "PROFILE\tTOKEN\tDATABASE\tAUTHORIZATION",
+	"profiles.default":                      "(default)",
+	"profiles.invalid_config":               "invalid configuration: %v",
+	"profiles.status.secrets_invalid":       "invalid SECRETS_*",
+	"profiles.status.not_authenticated":     "not authenticated",
+	"profiles.status.encrypted":             "encrypted token (missing or wrong key)",
+	"profiles.status.unreadable":            "unreadable token",
+	"profiles.status.saved":                 "token saved",
+	"profiles.status.client_secret_invalid": "invalid client_secret",
+	"profiles.status.revoked":               "revoked (run auth login)",
+	"profiles.status.error":                 "validation error",
+	"profiles.status.valid":                 "valid",
+
+	"backfill.usage": `Import into the local history the comments the channel already answered
+outside the tool (for example, in YouTube Studio).
+
+USAGE:
+  answer-comments backfill [options]
+
+Each page of 100 threads costs 1 quota unit. Progress is saved after every
+page: when -quota is reached or on Ctrl+C, the next run continues where it stopped.
+
+OPTIONS:
+`,
+	"backfill.flag.analyze":     "Classify the sentiment, score and theme of each imported comment with the LLM",
+	"backfill.flag.quota":       "Maximum YouTube API quota units to spend in this run",
+	"backfill.flag.restart":     "Ignore the saved progress and start again from the first page",
+	"backfill.quota_too_low":    "-quota must be at least 1.",
+	"backfill.page":             "page %d: %d answered, %d imported, %d already in the history",
+	"backfill.resumed":          "Resuming an interrupted backfill.",
+	"backfill.summary":          "%d pages, %d comments answered by the channel, %d imported, %d already in the history, %d quota units",
+	"backfill.summary.analyzed": ", %d classified",
+	"backfill.partial":          "Partial: %s. Run again to continue.",
+	"backfill.done":             "Backfill complete: %s",
+	"backfill.paused":           "Backfill paused: %s. Run again to continue.",
+
+	"config.usage": `Validate the configuration and show the effective values (secrets hidden).
+
+USAGE:
+  answer-comments config check  # check files, models, prompts and limits and show the configuration
+  answer-comments config show   # only show the effective configuration
+
+The configuration comes, in increasing order of precedence, from: defaults,
+config.yaml (or CONFIG_FILE), config.env, profiles/<name>.yaml, profiles/<name>.env
+and environment variables.
+`,
+	"config.errors.one":     "Configuration has %d error.",
+	"config.errors.other":   "Configuration has %d errors.",
+	"config.warnings.one":   "Configuration is valid, with %d warning.",
+	"config.warnings.other": "Configuration is valid, with %d warnings.",
+	"config.valid":          "Configuration is valid.",
+	"config.profile":        "# profile: %s",
+	"config.files":          "# files: %s",
+	"config.files.none":     "none (environment variables only)",
+	"config.print_error":    "Error showing the configuration: %v",
+
+	"costs.usage": `LLM spend report from the audit log (llm_calls table).
+
+USAGE:
+  answer-comments costs [options]
+
+The default prices (USD per million tokens) can be overridden by a JSON file
+in the format {"gemini-2.0-flash": {"input": 0.10, "output": 0.40}}.
+
+OPTIONS:
+`,
+	"costs.flag.since":       "Count calls from this date on (YYYY-MM-DD; default: the last 30 days)",
+	"costs.flag.by":          "Grouping: day, model or day-model",
+	"costs.flag.prices":      "JSON file with the price table (default: llm.prices_file from the configuration)",
+	"costs.flag.json":        "Print the report as JSON",
+	"costs.unknown_grouping": "Unknown grouping %q (use day, model or day-model).",
+	"costs.prices_error":     "Error loading the price table: %v",
+	"costs.calls_error":      "Error reading the call log: %v",
+	"costs.none":             "No LLM calls logged in the period.",
+	"costs.title":            "LLM spend since %s",
+	"costs.column.day":       "day",
+	"costs.column.model":     "model",
+	"costs.columns":          "calls\terrors\tinput tokens\toutput tokens\tcost (USD)",
+	"costs.total":            "total",
+	"costs.unpriced":         "Models missing from the price table (counted as zero cost): %s",
+
+	"db.usage": `Maintenance of the local database (DATABASE_FILE).
+
+USAGE:
+  answer-comments db info         # path, size and rows of each table
+  answer-comments db backup file  # write a consistent copy (even while the database is in use)
+  answer-comments db vacuum       # compact the file, reclaiming the space of deleted rows
+
+To move the history between databases, use "export" and "import".
+`,
+	"db.read_error":   "Error reading the database: %v",
+	"db.info":         "Database: %s (%s)",
+	"db.header":       "TABLE\tROWS",
+	"db.backup_error": "Error copying the database: %v",
+	"db.backup_done":  "Copy of %s written to %s (%s).",
+	"db.vacuum_error": "Error compacting the database: %v",
+	"db.vacuum_done":  "Database compacted: %s → %s.",
+
+	"eval.usage": `Evaluate the analysis and generation prompts against a labeled dataset.
+
+USAGE:
+  answer-comments eval [options] dataset.jsonl
+  answer-comments eval -sample N -out dataset.jsonl
+
+Each line of the dataset is a JSON object with the fields: id, comment, video_title,
+video_description, is_member, expected_sentiment, expected_theme and reference_answer.
+
+OPTIONS:
+`,
+	"eval.flag.sample":                "Write a dataset with N random comments from the database (requires -out) instead of evaluating",
+	"eval.flag.out":                   "Output file: the generated dataset (with -sample) or the full JSON report of the evaluation",
+	"eval.out_required":               "Give the output file of the dataset with -out.",
+	"eval.sample_error":               "Error sampling comments: %v",
+	"eval.dataset_save_error":         "Error saving the dataset: %v",
+	"eval.dataset_saved":              "Dataset with %d comments saved to %s. Review the labels before evaluating.",
+	"eval.dataset_error":              "Error loading the dataset: %v",
+	"eval.case_error":                 "error: %s",
+	"eval.report_save_error":          "Error saving the report: %v",
+	"eval.report_saved":               "Full report saved to %s",
+	"eval.report.cases":               "Cases evaluated:    %d (%d with errors)",
+	"eval.report.sentiment":           "Sentiment accuracy: %.1f%%",
+	"eval.report.theme":               "Theme accuracy:     %.1f%%",
+	"eval.report.judge":               "Similarity (judge): %.2f/5 (%.1f%%) over %d answers",
+	"eval.report.judge.none":          "Similarity (judge): — (no reference answers)",
+	"eval.report.confusion.sentiment": "Confusion matrix — sentiment (rows: expected, columns: predicted)",
+	"eval.report.confusion.theme":     "Confusion matrix — theme (rows: expected, columns: predicted)",
+	"eval.report.hit":                 "correct",
+
+	"export.usage": `Export the local history of comments and replies.
+
+USAGE:
+  answer-comments export [options]
+
+OPTIONS:
+`,
+	"export.flag.format":    "Format: csv or jsonl",
+	"export.flag.since":     "Export replies from this date on (YYYY-MM-DD; default: the whole history)",
+	"export.flag.theme":     "Export only comments with this theme",
+	"export.flag.out":       "Output file (default: standard output)",
+	"export.unknown_format": "Unknown format %q (use csv or jsonl).",
+	"export.error":          "Error exporting: %v",
+	"export.done":           "%d comments exported to %s",
+
+	"import.usage": `Import a file written by "answer-comments export" into the local history.
+
+USAGE:
+  answer-comments import [options] file.jsonl|file.csv
+
+Comments with the ID of one already saved are handled according to -on-conflict:
+  skip     keep the local comment
+  replace  overwrite it with the one in the file
+  newer    overwrite it only if the reply in the file is more recent
+
+OPTIONS:
+`,
+	"import.flag.format":      "Format: csv or jsonl (default: from the file extension)",
+	"import.flag.on_conflict": "ID conflicts: skip, replace or newer",
+	"import.flag.dry_run":     "Show what would be imported without writing anything",
+	"import.unknown_format":   "Unknown format %q (use -format csv or jsonl).",
+	"import.invalid_conflict": "Invalid value in -on-conflict %q (use skip, replace or newer).",
+	"import.read_error":       "Error reading %s: %v",
+	"import.error":            "Error importing: %v",
+	"import.summary":          "%d new, %d replaced, %d skipped (of %d in the file)",
+	"import.dry_run":          "Dry run, nothing was written: %s",
+	"import.done":             "Import complete: %s",
+
+	"search.usage": `Search the local history for comments whose text or reply contains the given
+text (case insensitive). Without text, list the most recent ones.
+
+USAGE:
+  answer-comments search [options] [text]
+
+OPTIONS:
+`,
+	"search.flag.author":    "Only comments by this author (display name on YouTube)",
+	"search.flag.sentiment": "Only comments with this sentiment (positivo, neutro, negativo)",
+	"search.flag.video":     "Only comments on this video (ID)",
+	"search.flag.since":     "Only replies from this date on (YYYY-MM-DD)",
+	"search.flag.limit":     "Maximum number of results (0 = all)",
+	"search.flag.json":      "Print the results as JSONL, in the export format",
+	"search.none":           "No comments found.",
+	"search.header":         "ANSWERED\tAUTHOR\tTHEME\tCOMMENT\tREPLY",
+	"search.limited":        "Showing the %d most recent; use -limit to see more.",
+
+	"secrets.usage": `Manage encrypted secrets (OAuth token and API keys).
+
+USAGE:
+  answer-comments secrets keygen file  # write a key file for SECRETS_KEY_FILE
+  answer-comments secrets encrypt      # read a value and print its enc:v1:... form for config.env
+  answer-comments secrets set NAME     # store a value in the system keyring (NAME=keyring:NAME)
+  answer-comments secrets migrate      # rewrite TOKEN_FILE with the current encryption/backend
+`,
+	"secrets.key_file_error":     "Could not create the key file: %v",
+	"secrets.keygen_done":        "Key written to %s. Set SECRETS_KEY_FILE=%s and keep a copy in a safe place.",
+	"secrets.no_key":             "Set SECRETS_PASSPHRASE or SECRETS_KEY_FILE before encrypting.",
+	"secrets.value_prompt":       "Value: ",
+	"secrets.value_of_prompt":    "Value of %s: ",
+	"secrets.empty_value":        "empty value",
+	"secrets.set_done":           "Stored in the keyring. Use %s=keyring:%s in config.env.",
+	"secrets.rewrite_error":      "Error rewriting the token: %v",
+	"secrets.migrated.keyring":   "Token moved to the system keyring.",
+	"secrets.migrated.encrypted": "Token rewritten encrypted in %s.",
+	"secrets.migrated.plain":     "Token rewritten in plain text: set SECRETS_PASSPHRASE or SECRETS_KEY_FILE to encrypt it.",
+
+	"stats.usage": `Reports on the replies saved in the local history.
+
+USAGE:
+  answer-comments stats [options]
+
+Includes replies per day and week, themes, sentiments, edit rate of the
+suggestions, median time to reply, top commenters, members and videos.
+
+OPTIONS:
+`,
+	"stats.flag.top":          `Number of authors in "top commenters"`,
+	"stats.flag.format":       "Output format: table, csv or json",
+	"stats.flag.out":          "Write the report to this file instead of standard output",
+	"stats.unknown_format":    "Unknown format %q (use table, csv or json).",
+	"stats.none":              "No replies in the history for the period.",
+	"stats.title":             "Replies since %s",
+	"stats.saved":             "Report saved to %s",
+	"stats.report.replies":    "Replies:              %d",
+	"stats.report.edited":     "Edited by reviewer:   %d (%.1f%%)",
+	"stats.report.median":     "Median time to reply: %s",
+	"stats.report.per_day":    "Replies per day",
+	"stats.report.per_week":   "Replies per week",
+	"stats.report.themes":     "Themes",
+	"stats.report.sentiments": "Sentiments",
+	"stats.report.top":        "Top commenters",
+	"stats.report.members":    "Members",
+	"stats.report.videos":     "Per video",
+	"stats.column.day":        "day",
+	"stats.column.week":       "week",
+	"stats.column.theme":      "theme",
+	"stats.column.sentiment":  "sentiment",
+	"stats.column.author":     "author",
+	"stats.column.group":      "group",
+	"stats.column.count":      "count",
+	"stats.columns.videos":    "video\treplies\tedited\tnegative\tmedian time",
+	"stats.no_data":           "(no data)",
+
+	"web.title":              "Unanswered comments",
+	"web.counts":             "%d in the queue · %d reviewed · %d members",
+	"web.all_videos":         "All videos",
+	"web.all_themes":         "All themes",
+	"web.filter":             "Filter",
+	"web.load":               "Fetch comments",
+	"web.load_next":          "Fetch next batch",
+	"web.chars":              "%d characters",
+	"web.answer_placeholder": "Type your reply",
+	"web.regenerate":         "Generate again",
+	"web.generate":           "Generate suggestion",
+	"web.no_match":           "No pending comments with these filters.",
+	"web.start":              "Click “Fetch comments” to load the first batch.",
+	"web.added.one":          "%d comment added to the queue.",
+	"web.added.other":        "%d comments added to the queue.",
+	"web.empty_answer":       "Empty reply — nothing was published.",
+	"web.not_found":          "comment not found in the queue",
+}
@@ -0,0 +1,130 @@
+// Package i18n is the message catalog of the user interface: the terminal
+// (help, messages, prompts and reports of every command), the web review UI and
+// the OAuth login. Messages are looked up by key in the current locale:
+// Brazilian Portuguese (the default) or English, chosen with --lang or the
+// usual locale variables (see Detect). Logs, the JSON API, error details from
+// the APIs and the prompts sent to the LLM are not translated.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Locale is a supported language.
+type Locale string
+
+const (
+	PtBR Locale = "pt-BR"
+	En   Locale = "en"
+)
+
+var catalogs = map[Locale]map[string]string{
+	PtBR: ptBR,
+	En:   en,
+}
+
+var (
+	mu      sync.RWMutex
+	current = PtBR
+	keys    = PtBR
+)
+
+// Parse recognizes locale names such as "en", "en_US.UTF-8", "pt_BR" or
+// "pt-BR". ok is false for anything else, including "C" and "POSIX".
+func Parse(name string) (l Locale, ok bool) {
+	name = strings.ToLower(name)
+	if i := strings.IndexAny(name, ".@"); i >= 0 {
+		name = name[:i]
+	}
+	lang, _, _ := strings.Cut(strings.ReplaceAll(name, "_", "-"), "-")
+	switch lang {
+	case "en":
+		return En, true
+	case "pt":
+		return PtBR, true
+	}
+	return "", false
+}
+
+// Detect returns the locale of the system: the one named by the first of
+// LC_ALL, LC_MESSAGES and LANG that is set; pt-BR when none is or the
+// language is not supported. The locale of this program alone
+// (ANSWER_COMMENTS_LANG) is part of the application configuration.
+func Detect() Locale {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(env)
+		if value == "" {
+			continue
+		}
+		if l, ok := Parse(value); ok {
+			return l
+		}
+		return PtBR
+	}
+	return PtBR
+}
+
+// Set selects the locale of the messages returned by T and N.
+func Set(l Locale) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = l
+}
+
+// Current returns the selected locale.
+func Current() Locale {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// SetKeys selects the locale of the action keys returned by Key. The keys are
+// kept apart from the messages so the ones a reviewer is used to do not change
+// with the system locale: they follow only a language chosen explicitly
+// (--lang or ANSWER_COMMENTS_LANG).
+func SetKeys(l Locale) {
+	mu.Lock()
+	defer mu.Unlock()
+	keys = l
+}
+
+// Key returns the key bound to the review menu action ("publish", "edit",
+// "skip", "quit") in the locale selected by SetKeys; pt-BR by default.
+func Key(action string) string {
+	mu.RLock()
+	l := keys
+	mu.RUnlock()
+	if k, ok := catalogs[l]["key."+action]; ok {
+		return k
+	}
+	return ptBR["key."+action]
+}
+
+// T returns the message key in the current locale, formatted with args when
+// there are any. A message missing from the locale falls back to pt-BR, and
+// an unknown key is returned as is.
+func T(key string, args ...any) string {
+	msg, ok := catalogs[Current()][key]
+	if !ok {
+		if msg, ok = ptBR[key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// N is T for messages that depend on a count: it uses key+".one" when n is 1
+// and key+".other" otherwise, with n as the first argument.
+func N(key string, n int, args ...any) string {
+	suffix := ".other"
+	if n == 1 {
+		suffix = ".one"
+	}
+	return T(key+suffix, append([]any{n}, args...)...)
+}
@@ -0,0 +1,63 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// verb matches a fmt verb, with its flags, width and precision.
+var verb = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+func TestCatalogsComplete(t *testing.T) {
+	for l, catalog := range catalogs {
+		if l == PtBR {
+			continue
+		}
+		for key, msg := range ptBR {
+			translated, ok := catalog[key]
+			if !ok {
+				t.Errorf("%s: falta a chave %q", l, key)
+				continue
+			}
+			// The arguments are passed in the same order in every locale
+			if want, got := verb.FindAllString(msg, -1), verb.FindAllString(translated, -1); !slices.Equal(got, want) {
+				t.Errorf("%s: %q tem os verbos %q, want %q", l, key, got, want)
+			}
+		}
+		for key := range catalog {
+			if _, ok := ptBR[key]; !ok {
+				t.Errorf("%s: a chave %q não existe em pt-BR", l, key)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	t.Cleanup(func() { Set(PtBR) })
+
+	tests := []struct {
+		locale Locale
+		key    string
+		args   []any
+		want   string
+	}{
+		{PtBR, "review.members_loaded", []any{3}, "Carregados 3 membros do canal."},
+		{En, "review.members_loaded", []any{3}, "Loaded 3 channel members."},
+		{En, "no.such.key", nil, "no.such.key"},
+	}
+	for _, tt := range tests {
+		Set(tt.locale)
+		if got := T(tt.key, tt.args...); got != tt.want {
+			t.Errorf("%s: T(%q) = %q, want %q", tt.locale, tt.key, got, tt.want)
+		}
+	}
+
+	Set(En)
+	if got := N("web.added", 1); got != "1 comment added to the queue." {
+		t.Errorf("N(1) = %q", got)
+	}
+	if got := N("web.added", 2); got != "2 comments added to the queue." {
+		t.Errorf("N(2) = %q", got)
+	}
+}
@@ -0,0 +1,547 @@
+package i18n
+
+// ptBR is the catalog of the default locale. Every key must be here: it is
+// the fallback of the other locales.
+var ptBR = map[string]string{
+	// Action keys of the review menu
+	"key.publish": "S",
+	"key.edit":    "E",
+	"key.skip":    "N",
+	"key.quit":    "Q",
+
+	"sentiment.positivo": "positivo",
+	"sentiment.negativo": "negativo",
+	"sentiment.neutro":   "neutro",
+
+	// internal/ui
+	"ui.member_badge":               "MEMBRO",
+	"ui.comment":                    "Comentário:",
+	"ui.action":                     "Ação:",
+	"ui.action.publish":             "Publicar",
+	"ui.action.edit":                "Editar",
+	"ui.action.skip":                "Pular",
+	"ui.action.quit":                "Sair",
+	"ui.edit_prompt":                "Digite sua resposta:",
+	"ui.searching":                  "Buscando novos comentários não respondidos...",
+	"ui.context.unavailable":        "indisponível",
+	"ui.context.interactions.one":   "%d interação",
+	"ui.context.interactions.other": "%d interações",
+	"ui.context.similar.one":        "%d similar",
+	"ui.context.similar.other":      "%d similares",
+	"ui.countdown":                  "%s %d:%02d... [Enter para editar]",
+
+	// Review session
+	"review.members_loaded":       "Carregados %d membros do canal.",
+	"review.press_enter_to_start": "Pressione Enter para iniciar a verificação de comentários...",
+	"review.all_pages_checked":    "Todas as páginas de comentários foram verificadas.",
+	"review.no_more_comments":     "Não há mais comentários não respondidos em todas as páginas disponíveis.",
+	"review.no_more_in_batch":     "Não há mais comentários não respondidos neste lote.",
+	"review.next_batch":           "Pressione Enter para buscar o próximo lote, ou digite %s para sair: ",
+	"review.header":               "Novo comentário não respondido encontrado",
+	"review.date_layout":          "02/01/2006 às 15:04",
+	"review.section.analysis":     "Análise do comentário",
+	"review.section.context":      "Contexto",
+	"review.section.suggestion":   "Sugestão de resposta",
+	"review.no_suggestion":        "Não foi possível gerar uma sugestão de resposta.",
+	"review.auto_publish":         "Resposta sugerida será publicada automaticamente.",
+	"review.not_suggested":        "Optei por não gerar uma resposta automática.",
+	"review.countdown.publish":    "Publicando em",
+	"review.countdown.skip":       "Pulando comentário em",
+	"review.countdown.sentiment":  "Sentimento %s —",
+	"review.countdown.score":      "Nota %d (mínimo %d) —",
+	"review.empty_answer":         "Resposta vazia — comentário ignorado.",
+	"review.not_published":        "Resposta não publicada.",
+	"review.published_not_saved":  "Resposta publicada, mas houve erro ao salvar no histórico local!",
+	"review.published":            "Resposta publicada e salva com sucesso!",
+
+	"members.csv_not_found":     "Arquivo de membros '%s' não encontrado.",
+	"members.csv_stale":         "Arquivo de membros desatualizado (mais de 10 dias).",
+	"members.csv_skipped.one":   "%d linha do arquivo de membros sem canal reconhecível foi ignorada.",
+	"members.csv_skipped.other": "%d linhas do arquivo de membros sem canal reconhecível foram ignoradas.",
+
+	"banner.manual":             "Modo Manual Ativado",
+	"banner.manual.desc":        "Todas as respostas deverão ser editadas manualmente.",
+	"banner.auto":               "Modo Auto-Resposta Ativado",
+	"banner.auto.desc":          "Respostas com alto nível de confiança serão publicadas automaticamente.",
+	"banner.transcription":      "Modo Transcrição Ativado",
+	"banner.transcription.desc": "A transcrição dos vídeos será usada como contexto para a LLM.",
+	"banner.debug":              "Modo de Debug Ativado",
+	"banner.debug.desc":         "Arquivo de debug será salvo.",
+
+	"session.authenticated":    "Autenticado com sucesso! ID do seu canal: %s",
+	"session.profile":          "Perfil: %s",
+	"metrics.available":        "Métricas disponíveis em http://%s/metrics",
+	"watch.interval_too_short": "-interval precisa ser de pelo menos 1m.",
+	"watch.next":               "Próxima verificação às %s (Ctrl+C para sair).",
+
+	// Flags of the commands that process comments
+	"flag.shortcut":      "Atalho para --%s",
+	"flag.manual":        "Modo manual: pula a sugestão da LLM e força edição manual de todas as respostas",
+	"flag.auto":          "Modo auto-resposta: todas as respostas sugeridas e com alto nível de confiança pela LLM serão publicadas automaticamente sem confirmação",
+	"flag.transcription": "Modo transcrição: usa a transcrição automática do vídeo como contexto para a LLM (exceto para comentários de Saudação/Agradecimento)",
+	"flag.debug":         "Ativa logging de debug em debug.log (ou caminho configurado com --debug-log)",
+	"flag.debug_log":     "Caminho do arquivo de log de debug (requer --debug)",
+	"flag.log_level":     "Nível de log, opcionalmente por subsistema: info,llm=debug,youtube=warn (padrão: LOG_LEVEL ou info)",
+	"flag.log_format":    "Formato do log: text ou json (padrão: LOG_FORMAT ou text)",
+	"flag.log_file":      "Arquivo de log; o terminal passa a mostrar só avisos e erros (padrão: LOG_FILE)",
+	"flag.metrics_addr":  "Expõe métricas Prometheus em http://<endereço>/metrics (padrão: metrics_addr da configuração; vazio desativa)",
+	"flag.interval":      "Intervalo entre as verificações (mínimo 1m)",
+
+	// Command list of the general help
+	"cmd.unknown":       "Comando desconhecido %q.",
+	"cmd.review.args":   "[opções]",
+	"cmd.review":        "Revisa os comentários não respondidos no terminal (padrão sem comando)",
+	"cmd.run.args":      "[opções]",
+	"cmd.run":           "Uma passada sem revisor: publica as respostas confiáveis e pula o resto",
+	"cmd.watch.args":    "[opções]",
+	"cmd.watch":         "Repete o run a cada intervalo, até Ctrl+C",
+	"cmd.serve.args":    "[opções]",
+	"cmd.serve":         "Interface web de revisão em http://127.0.0.1:8080/",
+	"cmd.api.args":      "[opções]",
+	"cmd.api":           "API JSON em http://127.0.0.1:8081/v1",
+	"cmd.stats.args":    "[opções]",
+	"cmd.stats":         "Relatórios de engajamento do histórico local",
+	"cmd.search.args":   "[opções] [texto]",
+	"cmd.search":        "Busca comentários e respostas no histórico local",
+	"cmd.export.args":   "[opções]",
+	"cmd.export":        "Exporta o histórico em CSV ou JSONL",
+	"cmd.import.args":   "[opções] arquivo",
+	"cmd.import":        "Importa um arquivo gerado por export",
+	"cmd.backfill.args": "[opções]",
+	"cmd.backfill":      "Importa respostas já publicadas no canal",
+	"cmd.db.args":       "info|backup|vacuum",
+	"cmd.db":            "Manutenção do banco de dados local",
+	"cmd.costs.args":    "[opções]",
+	"cmd.costs":         "Gasto com a LLM por dia e modelo",
+	"cmd.eval.args":     "[opções] dataset.jsonl",
+	"cmd.eval":          "Avalia prompts e modelos contra um dataset rotulado",
+	"cmd.auth.args":     "login|logout|status",
+	"cmd.auth":          "Autorização do canal no YouTube",
+	"cmd.profiles.args": "[opções]",
+	"cmd.profiles":      "Lista os perfis e o estado da autorização de cada um",
+	"cmd.secrets.args":  "keygen|encrypt|set|migrate",
+	"cmd.secrets":       "Segredos criptografados",
+	"cmd.config.args":   "check|show",
+	"cmd.config":        "Valida a configuração e mostra os valores efetivos",
+
+	"usage.intro": `YouTube Answer Comments - Assistente inteligente para responder comentários
+
+Esta ferramenta monitora comentários não respondidos no seu canal do YouTube
+e sugere respostas usando IA (Gemini), considerando o contexto do vídeo,
+histórico de interações e respostas anteriores similares.
+
+USO:
+  answer-comments [--lang idioma] [--profile nome] [comando] [opções]
+
+COMANDOS:
+`,
+	"usage.outro": `
+Sem comando, roda o review: "answer-comments -a" é o mesmo que "answer-comments review -a".
+Use "answer-comments help comando" ou "answer-comments comando -h" para as opções de cada comando.
+
+--profile nome (ou ANSWER_COMMENTS_PROFILE) vale para todos os comandos e usa
+profiles/nome.yaml ou .env: token, banco, membros e prompts próprios de cada canal.
+
+--lang pt-BR|en (ou ANSWER_COMMENTS_LANG, LC_ALL, LC_MESSAGES, LANG) escolhe o
+idioma do terminal. As teclas do menu de ações só mudam com --lang ou
+ANSWER_COMMENTS_LANG.
+
+EXEMPLOS:
+  answer-comments              # Revisão no terminal com sugestões da IA
+  answer-comments -a -t        # Revisão com publicação automática e transcrição
+  answer-comments run          # Passada sem revisor (cron, agendador)
+  answer-comments watch -interval 30m # Verifica novos comentários a cada 30 minutos
+  answer-comments search -author @fulano preço # Busca no histórico
+  answer-comments stats -since 2026-01-01 -format csv -out stats.csv # Relatórios de engajamento
+  answer-comments db backup copia.db # Cópia consistente do banco
+  answer-comments --profile canal-principal -a # Revisa outro canal
+  answer-comments --lang en    # Revisão com a interface em inglês
+  answer-comments config check # Valida a configuração
+`,
+	"usage.exit_codes": `CÓDIGOS DE SAÍDA:
+  0  Todos os comentários revisados ou sessão encerrada pelo usuário (%s)
+  1  Erro de inicialização ou de processamento
+  3  Erro de rede
+  4  Cota da API do YouTube esgotada
+  5  LLM (Gemini) indisponível
+`,
+
+	"review.usage": `Revisa no terminal os comentários não respondidos do canal, um por vez, com
+sugestões de resposta da IA. É o comando padrão quando nenhum é informado.
+
+USO:
+  answer-comments [review] [opções]
+
+OPÇÕES:
+`,
+	"review.usage.examples": `
+REQUISITOS:
+  - client_secret.json: Credenciais OAuth2 do YouTube API
+  - GEMINI_API_KEY: Variável de ambiente com a chave da API Gemini
+  - members.csv (opcional): Lista de membros do canal
+
+EXEMPLOS:
+  answer-comments              # Modo padrão com sugestões da IA
+  answer-comments -d           # Modo de debug (salva arquivo de debug)
+  answer-comments --log-level info,llm=debug --log-format json # Logs estruturados
+  answer-comments -m           # Modo manual (sem sugestões)
+  answer-comments -a           # Modo automático (publica sem confirmação)
+  answer-comments -t           # Usa transcrição dos vídeos como contexto
+  answer-comments -a -t        # Combina modo automático com transcrição
+  answer-comments -a --metrics-addr 127.0.0.1:9090 # Expõe /metrics para o Prometheus
+`,
+	"run.usage": `Verifica todos os comentários não respondidos uma vez, sem revisor: publica as
+respostas que o modo auto-resposta publicaria (review.publish_min_score) e pula
+as demais, sem perguntas nem contagens regressivas. Feito para cron e agendadores.
+
+USO:
+  answer-comments run [opções]
+
+OPÇÕES:
+`,
+	"watch.usage": `Repete o "run" a cada intervalo, até Ctrl+C: novos comentários são respondidos
+(ou pulados) sem revisor. Comentários já pulados não são analisados de novo
+enquanto o processo estiver rodando. Erros de rede, cota ou LLM interrompem só
+a passada atual; a próxima é tentada no intervalo seguinte.
+
+USO:
+  answer-comments watch [opções]
+
+OPÇÕES:
+`,
+
+	// Messages shared by the commands
+	"cmd.init_error":         "Erro ao inicializar aplicação: %v",
+	"cmd.option_needs_value": "--%s exige um valor",
+	"cmd.invalid_since":      "Data inválida em -since (use AAAA-MM-DD).",
+	"cmd.history_error":      "Erro ao ler o histórico: %v",
+	"cmd.create_error":       "Não foi possível criar %s: %v",
+	"cmd.no_token":           "Nenhum token salvo em %s.",
+	"cmd.gemini_key_missing": "GEMINI_API_KEY não configurada",
+	"cmd.date_layout":        "02/01/2006",
+	"flag.since":             "Considera respostas a partir desta data (AAAA-MM-DD; padrão: todo o histórico)",
+	"flag.theme":             "Somente comentários deste tema",
+
+	"api.usage": `Inicia a API HTTP JSON (/v1) sobre o pipeline de comentários.
+
+USO:
+  API_TOKEN=... answer-comments api [opções]
+
+Todas as rotas exigem o cabeçalho "Authorization: Bearer $API_TOKEN",
+exceto GET /v1/openapi.json, que descreve a API.
+
+OPÇÕES:
+`,
+	"api.flag.addr":    "Endereço de escuta da API",
+	"api.listening":    "API disponível em http://%s/v1 (documento em /v1/openapi.json)",
+	"api.server_error": "Erro no servidor da API: %v",
+
+	"serve.usage": `Inicia a interface web local de revisão de comentários.
+
+USO:
+  answer-comments serve [opções]
+
+OPÇÕES:
+`,
+	"serve.flag.addr":          "Endereço de escuta (somente localhost é aceito)",
+	"serve.flag.transcription": "Usa a transcrição automática do vídeo como contexto para a LLM",
+	"serve.prepare_error":      "Erro ao preparar a interface web: %v",
+	"serve.listening":          "Interface de revisão disponível em http://%s/ (Ctrl+C para encerrar)",
+	"serve.server_error":       "Erro no servidor web: %v",
+
+	"auth.usage": `Gerencia a autorização de acesso ao canal do YouTube.
+
+USO:
+  answer-comments auth login   # autoriza no navegador e salva o token
+  answer-comments auth logout  # revoga o token e apaga o arquivo
+  answer-comments auth status  # mostra se o token salvo é válido
+`,
+	"auth.login_done":        "Autorização concluída.",
+	"auth.logout_done":       "Token revogado e removido.",
+	"auth.not_authenticated": `Não autenticado: nenhum token em %s. Rode "answer-comments auth login".`,
+	"auth.unreadable":        "Token em %s ilegível: %v",
+	"auth.file":              "Arquivo",
+	"auth.no_refresh_token":  "O token não tem refresh token; será preciso autorizar de novo quando expirar.",
+	"auth.revoked":           "A autorização foi revogada ou expirou.",
+	"auth.validate_error":    "Não foi possível validar o token: %v",
+	"auth.login_again":       `Rode "answer-comments auth login" para autorizar de novo.`,
+	"auth.valid":             "Autenticado; o token é válido.",
+	"auth.open_browser":      "Abrindo o navegador para autorizar o aplicativo. Se ele não abrir, acesse:\n%s",
+	"auth.saving_token":      "Salvando o arquivo de credenciais em: %s",
+	"auth.reauthorize":       "A autorização salva foi revogada ou expirou; é preciso autorizar o aplicativo de novo.",
+	"auth.callback_failed":   "Falha na autorização: %v",
+	"auth.callback_done":     "Autorização concluída. Pode fechar esta aba e voltar ao terminal.",
+
+	"profiles.usage": `Lista os perfis (um por canal) e o estado da autorização de cada um.
+
+USO:
+  answer-comments profiles [opções]
+
+Cada perfil é um arquivo profiles/<nome>.yaml ou .env (diretório em PROFILES_DIR)
+selecionado com --profile <nome> em qualquer comando.
+
+OPÇÕES:
+`,
+	"profiles.flag.offline":                 "Não valida os tokens com o Google; mostra apenas se existem",
+	"profiles.list_error":                   "Erro ao listar os perfis: %v",
+	"profiles.none":                         "Nenhum perfil em profiles/; usando apenas a configuração padrão.",
+	"profiles.header":                       "PERFIL\tTOKEN\tBANCO\tAUTORIZAÇÃO",
+	"profiles.default":                      "(padrão)",
+	"profiles.invalid_config":               "configuração inválida: %v",
+	"profiles.status.secrets_invalid":       "SECRETS_* inválido",
+	"profiles.status.not_authenticated":     "não autenticado",
+	"profiles.status.encrypted":             "token criptografado (chave ausente ou incorreta)",
+	"profiles.status.unreadable":            "token ilegível",
+	"profiles.status.saved":                 "token salvo",
+	"profiles.status.client_secret_invalid": "client_secret inválido",
+	"profiles.status.revoked":               "revogado (rode auth login)",
+	"profiles.status.error":                 "erro ao validar",
+	"profiles.status.valid":                 "válido",
+
+	"backfill.usage": `Importa para o histórico local os comentários que o canal já respondeu fora da
+ferramenta (por exemplo, no YouTube Studio).
+
+USO:
+  answer-comments backfill [opções]
+
+Cada página de 100 threads custa 1 unidade de cota. O progresso é salvo a cada
+página: ao atingir -quota ou com Ctrl+C, a próxima execução continua de onde parou.
+
+OPÇÕES:
+`,
+	"backfill.flag.analyze":     "Classifica sentimento, nota e tema de cada comentário importado com a LLM",
+	"backfill.flag.quota":       "Máximo de unidades de cota da API do YouTube a gastar nesta execução",
+	"backfill.flag.restart":     "Ignora o progresso salvo e recomeça da primeira página",
+	"backfill.quota_too_low":    "-quota deve ser ao menos 1.",
+	"backfill.page":             "página %d: %d respondidos, %d importados, %d já no histórico",
+	"backfill.resumed":          "Continuação de um backfill interrompido.",
+	"backfill.summary":          "%d páginas, %d comentários respondidos pelo canal, %d importados, %d já no histórico, %d unidades de cota",
+	"backfill.summary.analyzed": ", %d classificados",
+	"backfill.partial":          "Parcial: %s. Rode de novo para continuar.",
+	"backfill.done":             "Backfill concluído: %s",
+	"backfill.paused":           "Backfill pausado: %s. Rode de novo para continuar.",
+
+	"config.usage": `Valida a configuração e mostra os valores efetivos (segredos ocultos).
+
+USO:
+  answer-comments config check  # verifica arquivos, modelos, prompts e limites e mostra a configuração
+  answer-comments config show   # só mostra a configuração efetiva
+
+A configuração vem, em ordem crescente de precedência, de: valores padrão,
+config.yaml (ou CONFIG_FILE), config.env, profiles/<nome>.yaml, profiles/<nome>.env
+e variáveis de ambiente.
+`,
+	"config.errors.one":     "Configuração com %d erro.",
+	"config.errors.other":   "Configuração com %d erros.",
+	"config.warnings.one":   "Configuração válida, com %d aviso.",
+	"config.warnings.other": "Configuração válida, com %d avisos.",
+	"config.valid":          "Configuração válida.",
+	"config.profile":        "# perfil: %s",
+	"config.files":          "# arquivos: %s",
+	"config.files.none":     "nenhum (só variáveis de ambiente)",
+	"config.print_error":    "Erro ao mostrar a configuração: %v",
+
+	"costs.usage": `Relatório de gasto com a LLM a partir do log de auditoria (tabela llm_calls).
+
+USO:
+  answer-comments costs [opções]
+
+Os preços padrão (USD por milhão de tokens) podem ser sobrescritos por um arquivo
+JSON no formato {"gemini-2.0-flash": {"input": 0.10, "output": 0.40}}.
+
+OPÇÕES:
+`,
+	"costs.flag.since":       "Considera chamadas a partir desta data (AAAA-MM-DD; padrão: últimos 30 dias)",
+	"costs.flag.by":          "Agrupamento: day, model ou day-model",
+	"costs.flag.prices":      "Arquivo JSON com a tabela de preços (padrão: llm.prices_file da configuração)",
+	"costs.flag.json":        "Imprime o relatório em JSON",
+	"costs.unknown_grouping": "Agrupamento desconhecido %q (use day, model ou day-model).",
+	"costs.prices_error":     "Erro ao carregar tabela de preços: %v",
+	"costs.calls_error":      "Erro ao ler o log de chamadas: %v",
+	"costs.none":             "Nenhuma chamada à LLM registrada no período.",
+	"costs.title":            "Gasto com a LLM desde %s",
+	"costs.column.day":       "dia",
+	"costs.column.model":     "modelo",
+	"costs.columns":          "chamadas\terros\ttokens entrada\ttokens saída\tcusto (USD)",
+	"costs.total":            "total",
+	"costs.unpriced":         "Modelos sem preço na tabela (contados como custo zero): %s",
+
+	"db.usage": `Manutenção do banco de dados local (DATABASE_FILE).
+
+USO:
+  answer-comments db info            # caminho, tamanho e linhas de cada tabela
+  answer-comments db backup arquivo  # grava uma cópia consistente (mesmo com o banco em uso)
+  answer-comments db vacuum          # compacta o arquivo, devolvendo o espaço de linhas apagadas
+
+Para mover o histórico entre bancos, use "export" e "import".
+`,
+	"db.read_error":   "Erro ao ler o banco: %v",
+	"db.info":         "Banco: %s (%s)",
+	"db.header":       "TABELA\tLINHAS",
+	"db.backup_error": "Erro ao copiar o banco: %v",
+	"db.backup_done":  "Cópia de %s gravada em %s (%s).",
+	"db.vacuum_error": "Erro ao compactar o banco: %v",
+	"db.vacuum_done":  "Banco compactado: %s → %s.",
+
+	"eval.usage": `Avalia os prompts de análise e geração contra um dataset rotulado.
+
+USO:
+  answer-comments eval [opções] dataset.jsonl
+  answer-comments eval -sample N -out dataset.jsonl
+
+Cada linha do dataset é um JSON com os campos: id, comment, video_title,
+video_description, is_member, expected_sentiment, expected_theme e reference_answer.
+
+OPÇÕES:
+`,
+	"eval.flag.sample":                "Gera um dataset com N comentários aleatórios do banco (requer -out) em vez de avaliar",
+	"eval.flag.out":                   "Arquivo de saída: dataset gerado (com -sample) ou relatório JSON completo da avaliação",
+	"eval.out_required":               "Informe o arquivo de saída do dataset com -out.",
+	"eval.sample_error":               "Erro ao amostrar comentários: %v",
+	"eval.dataset_save_error":         "Erro ao salvar dataset: %v",
+	"eval.dataset_saved":              "Dataset com %d comentários salvo em %s. Revise os rótulos antes de avaliar.",
+	"eval.dataset_error":              "Erro ao carregar dataset: %v",
+	"eval.case_error":                 "erro: %s",
+	"eval.report_save_error":          "Erro ao salvar relatório: %v",
+	"eval.report_saved":               "Relatório completo salvo em %s",
+	"eval.report.cases":               "Casos avaliados:        %d (%d com erro)",
+	"eval.report.sentiment":           "Acurácia de sentimento: %.1f%%",
+	"eval.report.theme":               "Acurácia de tema:       %.1f%%",
+	"eval.report.judge":               "Similaridade (juiz):    %.2f/5 (%.1f%%) em %d respostas",
+	"eval.report.judge.none":          "Similaridade (juiz):    — (nenhuma resposta de referência)",
+	"eval.report.confusion.sentiment": "Matriz de confusão — sentimento (linhas: esperado, colunas: previsto)",
+	"eval.report.confusion.theme":     "Matriz de confusão — tema (linhas: esperado, colunas: previsto)",
+	"eval.report.hit":                 "acerto",
+
+	"export.usage": `Exporta o histórico local de comentários e respostas.
+
+USO:
+  answer-comments export [opções]
+
+OPÇÕES:
+`,
+	"export.flag.format":    "Formato: csv ou jsonl",
+	"export.flag.since":     "Exporta respostas a partir desta data (AAAA-MM-DD; padrão: todo o histórico)",
+	"export.flag.theme":     "Exporta apenas comentários deste tema",
+	"export.flag.out":       "Arquivo de saída (padrão: saída padrão)",
+	"export.unknown_format": "Formato desconhecido %q (use csv ou jsonl).",
+	"export.error":          "Erro ao exportar: %v",
+	"export.done":           "%d comentários exportados para %s",
+
+	"import.usage": `Importa um arquivo gerado por "answer-comments export" para o histórico local.
+
+USO:
+  answer-comments import [opções] arquivo.jsonl|arquivo.csv
+
+Comentários com o mesmo ID de um já salvo são tratados conforme -on-conflict:
+  skip     mantém o comentário local
+  replace  sobrescreve com o do arquivo
+  newer    sobrescreve só se a resposta do arquivo for mais recente
+
+OPÇÕES:
+`,
+	"import.flag.format":      "Formato: csv ou jsonl (padrão: pela extensão do arquivo)",
+	"import.flag.on_conflict": "Conflitos de ID: skip, replace ou newer",
+	"import.flag.dry_run":     "Mostra o que seria importado sem gravar nada",
+	"import.unknown_format":   "Formato desconhecido %q (use -format csv ou jsonl).",
+	"import.invalid_conflict": "Valor inválido em -on-conflict %q (use skip, replace ou newer).",
+	"import.read_error":       "Erro ao ler %s: %v",
+	"import.error":            "Erro ao importar: %v",
+	"import.summary":          "%d novos, %d substituídos, %d ignorados (de %d no arquivo)",
+	"import.dry_run":          "Simulação, nada foi gravado: %s",
+	"import.done":             "Importação concluída: %s",
+
+	"search.usage": `Busca no histórico local os comentários cujo texto ou resposta contém o texto
+informado (sem diferenciar maiúsculas). Sem texto, lista os mais recentes.
+
+USO:
+  answer-comments search [opções] [texto]
+
+OPÇÕES:
+`,
+	"search.flag.author":    "Somente comentários deste autor (nome exibido no YouTube)",
+	"search.flag.sentiment": "Somente comentários com este sentimento (positivo, neutro, negativo)",
+	"search.flag.video":     "Somente comentários deste vídeo (ID)",
+	"search.flag.since":     "Somente respostas a partir desta data (AAAA-MM-DD)",
+	"search.flag.limit":     "Quantidade máxima de resultados (0 = todos)",
+	"search.flag.json":      "Imprime os resultados em JSONL, no formato do export",
+	"search.none":           "Nenhum comentário encontrado.",
+	"search.header":         "RESPONDIDO\tAUTOR\tTEMA\tCOMENTÁRIO\tRESPOSTA",
+	"search.limited":        "Mostrando os %d mais recentes; use -limit para ver mais.",
+
+	"secrets.usage": `Gerencia segredos criptografados (token OAuth e chaves de API).
+
+USO:
+  answer-comments secrets keygen arquivo  # gera um arquivo de chave para SECRETS_KEY_FILE
+  answer-comments secrets encrypt         # lê um valor e imprime a forma enc:v1:... para o config.env
+  answer-comments secrets set NOME        # guarda um valor no chaveiro do sistema (NOME=keyring:NOME)
+  answer-comments secrets migrate         # regrava TOKEN_FILE com a criptografia/backend atuais
+`,
+	"secrets.key_file_error":     "Não foi possível criar o arquivo de chave: %v",
+	"secrets.keygen_done":        "Chave gerada em %s. Defina SECRETS_KEY_FILE=%s e guarde uma cópia em local seguro.",
+	"secrets.no_key":             "Defina SECRETS_PASSPHRASE ou SECRETS_KEY_FILE antes de criptografar.",
+	"secrets.value_prompt":       "Valor: ",
+	"secrets.value_of_prompt":    "Valor de %s: ",
+	"secrets.empty_value":        "valor vazio",
+	"secrets.set_done":           "Guardado no chaveiro. Use %s=keyring:%s no config.env.",
+	"secrets.rewrite_error":      "Erro ao regravar o token: %v",
+	"secrets.migrated.keyring":   "Token movido para o chaveiro do sistema.",
+	"secrets.migrated.encrypted": "Token regravado criptografado em %s.",
+	"secrets.migrated.plain":     "Token regravado em texto puro: defina SECRETS_PASSPHRASE ou SECRETS_KEY_FILE para criptografar.",
+
+	"stats.usage": `Relatórios sobre as respostas salvas no histórico local.
+
+USO:
+  answer-comments stats [opções]
+
+Inclui respostas por dia e semana, temas, sentimentos, taxa de edição das
+sugestões, tempo mediano até a resposta, quem mais comenta, membros e vídeos.
+
+OPÇÕES:
+`,
+	"stats.flag.top":          `Quantidade de autores em "quem mais comenta"`,
+	"stats.flag.format":       "Formato de saída: table, csv ou json",
+	"stats.flag.out":          "Grava o relatório neste arquivo em vez da saída padrão",
+	"stats.unknown_format":    "Formato desconhecido %q (use table, csv ou json).",
+	"stats.none":              "Nenhuma resposta no histórico para o período.",
+	"stats.title":             "Respostas desde %s",
+	"stats.saved":             "Relatório salvo em %s",
+	"stats.report.replies":    "Respostas:                  %d",
+	"stats.report.edited":     "Editadas pelo revisor:      %d (%.1f%%)",
+	"stats.report.median":     "Tempo mediano até resposta: %s",
+	"stats.report.per_day":    "Respostas por dia",
+	"stats.report.per_week":   "Respostas por semana",
+	"stats.report.themes":     "Temas",
+	"stats.report.sentiments": "Sentimentos",
+	"stats.report.top":        "Quem mais comenta",
+	"stats.report.members":    "Membros",
+	"stats.report.videos":     "Por vídeo",
+	"stats.column.day":        "dia",
+	"stats.column.week":       "semana",
+	"stats.column.theme":      "tema",
+	"stats.column.sentiment":  "sentimento",
+	"stats.column.author":     "autor",
+	"stats.column.group":      "grupo",
+	"stats.column.count":      "qtd",
+	"stats.columns.videos":    "vídeo\trespostas\teditadas\tnegativos\ttempo mediano",
+	"stats.no_data":           "(sem dados)",
+
+	// internal/web
+	"web.title":              "Comentários não respondidos",
+	"web.counts":             "%d na fila · %d revisados · %d membros",
+	"web.all_videos":         "Todos os vídeos",
+	"web.all_themes":         "Todos os temas",
+	"web.filter":             "Filtrar",
+	"web.load":               "Buscar comentários",
+	"web.load_next":          "Buscar próximo lote",
+	"web.chars":              "%d caracteres",
+	"web.answer_placeholder": "Digite sua resposta",
+	"web.regenerate":         "Gerar novamente",
+	"web.generate":           "Gerar sugestão",
+	"web.no_match":           "Nenhum comentário pendente com estes filtros.",
+	"web.start":              "Clique em “Buscar comentários” para carregar o primeiro lote.",
+	"web.added.one":          "%d comentário adicionado à fila.",
+	"web.added.other":        "%d comentários adicionados à fila.",
+	"web.empty_answer":       "Resposta vazia — nada foi publicado.",
+	"web.not_found":          "comentário não encontrado na fila",
+}
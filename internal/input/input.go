@@ -0,0 +1,100 @@
+// Package input abstracts where the reviewer's answers come from, so the
+// interactive flow can be driven by a script in tests.
+package input
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Source delivers the lines typed by the reviewer, one per Enter, without the
+// line terminator. The channel is closed when no more input is possible.
+type Source interface {
+	Lines() <-chan string
+}
+
+// Reader is a Source backed by an io.Reader. A single goroutine reads the
+// whole session, started on the first call to Lines: a goroutine blocked in
+// ReadString cannot be cancelled, so every consumer (prompts, countdowns)
+// must share the same channel instead of reading on its own.
+type Reader struct {
+	r    io.Reader
+	once sync.Once
+	ch   chan string
+}
+
+// NewReader creates a Source reading lines from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r, ch: make(chan string, 1)}
+}
+
+// Stdin returns a Source reading from the terminal.
+func Stdin() *Reader {
+	return NewReader(os.Stdin)
+}
+
+func (r *Reader) Lines() <-chan string {
+	r.once.Do(func() {
+		go func() {
+			defer close(r.ch)
+			reader := bufio.NewReader(r.r)
+			for {
+				line, err := reader.ReadString('\n')
+				if line != "" || err == nil {
+					r.ch <- strings.TrimRight(line, "\r\n")
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+	})
+	return r.ch
+}
+
+// Script is a Source fed by a test. Lines given to NewScript are delivered
+// first, in order; Send adds more while the flow runs. Delivery is unbuffered,
+// so Send returns only once the flow has read the line, which keeps scripted
+// countdown interactions deterministic.
+type Script struct {
+	ch      chan string
+	drained chan struct{}
+	once    sync.Once
+}
+
+// NewScript creates a Script that first delivers lines, in order.
+func NewScript(lines ...string) *Script {
+	s := &Script{ch: make(chan string), drained: make(chan struct{})}
+	go func() {
+		for _, l := range lines {
+			s.ch <- l
+		}
+		close(s.drained)
+	}()
+	return s
+}
+
+func (s *Script) Lines() <-chan string {
+	return s.ch
+}
+
+// Send delivers one more line and waits until the flow reads it. It must not
+// be called after Close.
+func (s *Script) Send(line string) {
+	<-s.drained
+	s.ch <- line
+}
+
+// Close ends the script once the initial lines were read; the flow then sees
+// the input as finished.
+func (s *Script) Close() {
+	s.once.Do(func() {
+		go func() {
+			<-s.drained
+			close(s.ch)
+		}()
+	})
+}
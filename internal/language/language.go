@@ -0,0 +1,116 @@
+// Package language identifies the language of a comment, so the reply and
+// the video transcript used as context can match it. Languages are ISO 639-1
+// codes ("pt", "es", "en").
+package language
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Languages recognized by Detect.
+const (
+	Portuguese = "pt"
+	Spanish    = "es"
+	English    = "en"
+)
+
+// names are the language names used in the prompts, which are in Portuguese.
+var names = map[string]string{
+	Portuguese: "português",
+	Spanish:    "espanhol",
+	English:    "inglês",
+	"fr":       "francês",
+	"it":       "italiano",
+	"de":       "alemão",
+}
+
+// stopwords are frequent words of each language. Words common to two of them
+// ("que", "de", "com", "para", "no"...) are left out: they do not help to
+// tell the languages apart.
+var stopwords = map[string]map[string]bool{
+	Portuguese: set("não", "você", "vocês", "muito", "muita", "obrigado", "obrigada", "é", "são", "estou",
+		"isso", "uma", "um", "da", "dos", "das", "na", "nas", "pra", "meu", "minha", "também",
+		"ele", "ela", "eu", "tem", "seu", "sua", "então", "mais", "já", "só", "aqui", "parabéns",
+		"ótimo", "ótima", "vídeo", "vídeos", "legal", "gente", "fiz", "quero", "ao", "às", "nós"),
+	Spanish: set("el", "los", "las", "una", "es", "pero", "muy", "gracias", "usted", "ustedes", "yo",
+		"del", "al", "y", "esto", "eso", "qué", "cómo", "también", "más", "hay", "hola", "soy",
+		"eres", "tengo", "mucho", "mucha", "muchas", "muchos", "bueno", "buen", "ahora", "puedo",
+		"hacer", "mi", "tu", "tus", "sus", "nosotros", "ya", "aquí", "felicidades", "genial"),
+	English: set("the", "and", "is", "are", "you", "your", "this", "that", "it", "of", "to", "in",
+		"for", "with", "thanks", "thank", "great", "very", "love", "i", "my", "what", "how", "not",
+		"but", "was", "be", "have", "just", "so", "can", "awesome", "please", "would"),
+}
+
+// letters only appear in one of the languages and count as much as a word.
+var letters = map[rune]string{
+	'ã': Portuguese,
+	'õ': Portuguese,
+	'ç': Portuguese,
+	'ñ': Spanish,
+	'¿': Spanish,
+	'¡': Spanish,
+}
+
+func set(words ...string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}
+
+// Detect guesses whether text is in Portuguese, Spanish or English by
+// counting typical words and letters. It returns "" when there is nothing to
+// tell them apart or it is a tie, as for a single emoji or a name.
+func Detect(text string) string {
+	text = strings.ToLower(text)
+	score := make(map[string]int)
+	for _, r := range text {
+		if l, ok := letters[r]; ok {
+			score[l]++
+		}
+	}
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, w := range words {
+		for l, sw := range stopwords {
+			if sw[w] {
+				score[l]++
+			}
+		}
+	}
+
+	var best string
+	var bestScore, second int
+	for _, l := range []string{Portuguese, Spanish, English} {
+		switch s := score[l]; {
+		case s > bestScore:
+			best, bestScore, second = l, s, bestScore
+		case s > second:
+			second = s
+		}
+	}
+	if bestScore == 0 || bestScore == second {
+		return ""
+	}
+	return best
+}
+
+// Normalize reduces a language tag such as "pt-BR", "es_419" or "EN" to its
+// lowercase ISO 639-1 code.
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	code, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	return code
+}
+
+// Name returns the name of the language in Portuguese, for the prompts, or
+// the code itself when it is not known.
+func Name(code string) string {
+	if name, ok := names[code]; ok {
+		return name
+	}
+	return code
+}
@@ -0,0 +1,47 @@
+package language
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"portuguese", "Muito obrigado pelo vídeo, você é demais!", Portuguese},
+		{"spanish", "Muchas gracias, el tutorial es muy bueno", Spanish},
+		{"english", "Thanks for the great video, I love this channel", English},
+		{"portuguese letters only", "Ação e emoção", Portuguese},
+		{"spanish letters only", "¡Mañana!", Spanish},
+		{"uppercase", "MUITO OBRIGADO, PARABÉNS", Portuguese},
+		{"mixed, english wins", "Obrigado! This is great and very helpful", English},
+		{"mixed, portuguese wins", "Nice! Muito legal, você é demais, parabéns", Portuguese},
+		{"tie", "Thanks, obrigado", ""},
+		{"three-way tie", "Thanks, obrigado, gracias", ""},
+		{"emoji only", "🔥🔥👏", ""},
+		{"name only", "Carlos", ""},
+		{"words common to pt and es", "que de con para", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.text); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"pt-BR":  "pt",
+		"es_419": "es",
+		" EN ":   "en",
+		"":       "",
+	}
+	for tag, want := range tests {
+		if got := Normalize(tag); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"answer-comments/internal/database"
+	"answer-comments/internal/logging"
+
+	"google.golang.org/genai"
+)
+
+type commentIDKey struct{}
+
+// WithCommentID returns a context whose LLM calls are linked to commentID in
+// the audit log.
+func WithCommentID(ctx context.Context, commentID string) context.Context {
+	return context.WithValue(ctx, commentIDKey{}, commentID)
+}
+
+func commentIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(commentIDKey{}).(string)
+	return id
+}
+
+// audit stores the call in the llm_calls table. The full prompt text is kept
+// only with fullPrompt (Config.AuditPrompts); by default only its hash is
+// stored, since prompts carry the video transcript and the author's history
+// and grow the database quickly. Failures are logged and do not affect the
+// caller.
+func audit(ctx context.Context, fullPrompt bool, model, operation, prompt string, resp *genai.GenerateContentResponse, latency time.Duration, callErr error) {
+	hash := sha256.Sum256([]byte(prompt))
+	call := database.LLMCall{
+		CommentID:  commentIDFrom(ctx),
+		Operation:  operation,
+		Model:      model,
+		PromptHash: hex.EncodeToString(hash[:]),
+		Latency:    latency,
+	}
+	if fullPrompt {
+		call.Prompt = prompt
+	}
+	if callErr != nil {
+		call.Error = callErr.Error()
+	}
+	if resp != nil {
+		call.Response = resp.Text()
+		if usage := resp.UsageMetadata; usage != nil {
+			call.PromptTokens = int(usage.PromptTokenCount)
+			call.CandidateTokens = int(usage.CandidatesTokenCount)
+			call.ThoughtsTokens = int(usage.ThoughtsTokenCount)
+		}
+	}
+	if err := database.SaveLLMCall(call); err != nil {
+		logger.Error("erro ao registrar chamada no log de auditoria", logging.KeyCommentID, call.CommentID, logging.KeyModel, model, logging.KeyError, err)
+	}
+}
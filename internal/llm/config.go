@@ -0,0 +1,111 @@
+package llm
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// Config holds the models and prompt templates used by a Client.
+type Config struct {
+	AnalysisModel   string `yaml:"analysis_model" env:"LLM_ANALYSIS_MODEL"`
+	GenerationModel string `yaml:"generation_model" env:"LLM_GENERATION_MODEL"`
+	// JudgeModel grades answers during evaluations; "" uses GenerationModel.
+	JudgeModel string `yaml:"judge_model" env:"LLM_JUDGE_MODEL"`
+	// AuditPrompts stores the full prompt text in llm_calls, not only its
+	// hash.
+	AuditPrompts bool `yaml:"audit_prompts" env:"LLM_AUDIT_PROMPTS"`
+	// PricesFile overrides the price table used by the costs report.
+	PricesFile string  `yaml:"prices_file" env:"LLM_PRICES_FILE"`
+	Prompts    Prompts `yaml:"prompts"`
+}
+
+// Prompts are the prompt templates. See placeholders for the {{...}}
+// placeholders each one accepts.
+type Prompts struct {
+	Analysis       string `yaml:"analysis" env:"PROMPT_ANALYSIS"`
+	PositiveAnswer string `yaml:"positive_answer" env:"PROMPT_POSITIVE_ANSWER"`
+	NegativeAnswer string `yaml:"negative_answer" env:"PROMPT_NEGATIVE_ANSWER"`
+	// Judge is optional; a built-in prompt is used when empty.
+	Judge string `yaml:"judge" env:"PROMPT_JUDGE"`
+}
+
+// DefaultConfig returns the default models. The channel-specific prompts
+// have no default.
+func DefaultConfig() Config {
+	return Config{
+		AnalysisModel:   "gemini-2.0-flash-lite",
+		GenerationModel: "gemini-2.0-flash",
+	}
+}
+
+// Client calls the Gemini models with the configured prompts.
+type Client struct {
+	gemini *genai.Client
+	cfg    Config
+}
+
+// NewClient returns a Client over gemini.
+func NewClient(gemini *genai.Client, cfg Config) *Client {
+	return &Client{gemini: gemini, cfg: cfg}
+}
+
+// Config returns the configuration of the client.
+func (c *Client) Config() Config {
+	return c.cfg
+}
+
+func (c *Client) judgeModel() string {
+	if c.cfg.JudgeModel == "" {
+		return c.cfg.GenerationModel
+	}
+	return c.cfg.JudgeModel
+}
+
+var answerPlaceholders = []string{"COMMENT", "TITLE", "DESCRIPTION", "TRANSCRIPT", "HISTORY", "CONSISTENCY", "MEMBER_NOTICE", "LANGUAGE"}
+
+// placeholders lists, per prompt, the accepted placeholders; the first one
+// is required.
+var placeholders = map[string][]string{
+	"analysis":        {"COMMENT"},
+	"positive_answer": answerPlaceholders,
+	"negative_answer": answerPlaceholders,
+	"judge":           {"COMMENT", "REFERENCE", "CANDIDATE"},
+}
+
+var placeholderRe = regexp.MustCompile(`\{\{([A-Za-z_]+)\}\}`)
+
+// Check reports missing prompts, placeholders a prompt does not accept (they
+// would reach the model verbatim) and required placeholders that are absent.
+func (p Prompts) Check() []error {
+	var errs []error
+	for _, t := range []struct {
+		name, template string
+		optional       bool
+	}{
+		{"analysis", p.Analysis, false},
+		{"positive_answer", p.PositiveAnswer, false},
+		{"negative_answer", p.NegativeAnswer, false},
+		{"judge", p.Judge, true},
+	} {
+		if t.template == "" {
+			if !t.optional {
+				errs = append(errs, fmt.Errorf("prompt %s não configurado", t.name))
+			}
+			continue
+		}
+		accepted := placeholders[t.name]
+		for _, m := range placeholderRe.FindAllStringSubmatch(t.template, -1) {
+			if !slices.Contains(accepted, m[1]) {
+				errs = append(errs, fmt.Errorf("prompt %s: placeholder desconhecido {{%s}}", t.name, m[1]))
+			}
+		}
+		if required := "{{" + accepted[0] + "}}"; !strings.Contains(t.template, required) {
+			errs = append(errs, fmt.Errorf("prompt %s: falta o placeholder %s", t.name, required))
+		}
+	}
+	return errs
+}
@@ -4,82 +4,123 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
+	"answer-comments/internal/language"
+	"answer-comments/internal/logging"
+	"answer-comments/internal/metrics"
 	"answer-comments/internal/models"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/genai"
 )
 
-// getAnalysisModel returns the model for analysis
-func getAnalysisModel() string {
-	model := os.Getenv("LLM_ANALYSIS_MODEL")
-	if model == "" {
-		return "gemini-2.0-flash-lite"
+var logger = logging.For(logging.LLM)
+
+// defaultJudgePrompt is used when Prompts.Judge is not set. Unlike the answer
+// prompts it is not channel specific, so the tool ships a sensible default.
+const defaultJudgePrompt = `Você é um avaliador de respostas a comentários do YouTube.
+Compare a RESPOSTA CANDIDATA com a RESPOSTA DE REFERÊNCIA escrita pelo dono do canal para o mesmo comentário.
+Avalie o quanto a candidata transmite o mesmo conteúdo, intenção e tom da referência, numa escala de 1 (nada parecida) a 5 (equivalente).
+Responda apenas com um JSON no formato {"nota": <1-5>, "justificativa": "<uma frase>"}.
+
+Comentário: "{{COMMENT}}"
+Resposta de referência: "{{REFERENCE}}"
+Resposta candidata: "{{CANDIDATE}}"`
+
+// JudgeAnswer asks the judge model how close a candidate answer is to a
+// reference answer. It returns a score from 1 to 5.
+func (c *Client) JudgeAnswer(ctx context.Context, comment string, reference string, candidate string) (int, error) {
+	prompt := c.cfg.Prompts.Judge
+	if prompt == "" {
+		prompt = defaultJudgePrompt
 	}
-	return model
-}
+	prompt = strings.ReplaceAll(prompt, "{{COMMENT}}", comment)
+	prompt = strings.ReplaceAll(prompt, "{{REFERENCE}}", reference)
+	prompt = strings.ReplaceAll(prompt, "{{CANDIDATE}}", candidate)
 
-// getGenerationModel returns the model for generation
-func getGenerationModel() string {
-	model := os.Getenv("LLM_GENERATION_MODEL")
-	if model == "" {
-		return "gemini-2.0-flash"
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	resp, err := c.generate(ctx, c.judgeModel(), "judge", prompt)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao avaliar resposta com Gemini: %w", err)
+	}
+
+	raw := resp.Text()
+	var verdict struct {
+		Nota int `json:"nota"`
 	}
-	return model
+	if err := json.Unmarshal([]byte(stripCodeFence(raw)), &verdict); err != nil {
+		return 0, fmt.Errorf("parsing JSON judge LLM: %w; raw: %s", err, raw)
+	}
+	if verdict.Nota < 1 || verdict.Nota > 5 {
+		return 0, fmt.Errorf("nota fora do intervalo 1-5 retornada pelo juiz: %d", verdict.Nota)
+	}
+	return verdict.Nota, nil
 }
 
 // AnalyzeComment sends the comment to a smaller/cheaper LLM to get nota and sentimento.
-func AnalyzeComment(ctx context.Context, comment string, genaiClient *genai.Client) (models.SentimentAnalysis, error) {
-	prompt := getAnalysisPrompt(comment)
+func (c *Client) AnalyzeComment(ctx context.Context, comment string) (models.SentimentAnalysis, error) {
+	prompt := getAnalysisPrompt(c.cfg.Prompts.Analysis, comment)
 
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
-	resp, err := genaiClient.Models.GenerateContent(
-		ctx,
-		getAnalysisModel(),
-		genai.Text(prompt),
-		nil,
-	)
+	resp, err := c.generate(ctx, c.cfg.AnalysisModel, "analysis", prompt)
 	if err != nil {
 		return models.SentimentAnalysis{}, fmt.Errorf("erro ao analisar comentario com Gemini: %w", err)
 	}
 
 	raw := resp.Text()
-	cleaned := strings.TrimPrefix(raw, "```json")
-	cleaned = strings.TrimPrefix(cleaned, "```")
-	cleaned = strings.TrimSuffix(cleaned, "```")
-	cleaned = strings.TrimSpace(cleaned)
 
 	var s models.SentimentAnalysis
-	if err := json.Unmarshal([]byte(cleaned), &s); err != nil {
+	if err := json.Unmarshal([]byte(stripCodeFence(raw)), &s); err != nil {
 		return models.SentimentAnalysis{}, fmt.Errorf("parsing JSON analysis LLM: %w; raw: %s", err, raw)
 	}
+	// The analysis prompt may ask for the language; otherwise it is detected
+	// locally
+	s.Idioma = language.Normalize(s.Idioma)
+	if s.Idioma == "" {
+		s.Idioma = language.Detect(comment)
+	}
 	return s, nil
 }
 
-// suggestAnswer uses the GenerationModel to produce a response text for a given comment.
-func SuggestAnswer(ctx context.Context, isANegativeComment bool, comment string, videoTitle string, videoDescription string, videoTranscript string, authorHistory []models.Comment, isMember bool, ragContext []string, genaiClient *genai.Client) (string, error) {
+// AnswerRequest is a comment to answer with SuggestAnswer and the context
+// that goes into the prompt.
+type AnswerRequest struct {
+	Comment  string
+	Negative bool   // uses Prompts.NegativeAnswer instead of Prompts.PositiveAnswer
+	Language string // of the comment, see models.SentimentAnalysis.Idioma
+
+	VideoTitle       string
+	VideoDescription string
+	VideoTranscript  string // "" when not fetched
+
+	AuthorHistory []models.Comment // past interactions with the author
+	Member        *models.Member   // nil for non-members
+	PastAnswers   []string         // similar past answers (RAG)
+}
+
+// SuggestAnswer uses the GenerationModel to produce a response text for a
+// given comment, in the comment's language.
+func (c *Client) SuggestAnswer(ctx context.Context, req AnswerRequest) (string, error) {
 
 	var prompt string
-	if isANegativeComment {
-		prompt = getNegativeAnswerPrompt(comment, videoTitle, videoDescription, videoTranscript, authorHistory, isMember, ragContext)
+	if req.Negative {
+		prompt = getNegativeAnswerPrompt(c.cfg.Prompts.NegativeAnswer, req.Comment, req.VideoTitle, req.VideoDescription, req.VideoTranscript, req.AuthorHistory, req.Member, req.PastAnswers)
 	} else {
-		prompt = getPositiveAnswerPrompt(comment, videoTitle, videoDescription, videoTranscript, authorHistory, isMember, ragContext)
+		prompt = getPositiveAnswerPrompt(c.cfg.Prompts.PositiveAnswer, req.Comment, req.VideoTitle, req.VideoDescription, req.VideoTranscript, req.AuthorHistory, req.Member, req.PastAnswers)
 	}
+	prompt = withLanguageNotice(prompt, req.Language)
 
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
-	resp, err := genaiClient.Models.GenerateContent(
-		ctx,
-		getGenerationModel(),
-		genai.Text(prompt),
-		nil,
-	)
+	resp, err := c.generate(ctx, c.cfg.GenerationModel, "generation", prompt)
 	if err != nil {
 		return "", fmt.Errorf("erro ao gerar conte\u00fado com Gemini: %w", err)
 	}
@@ -93,9 +134,26 @@ func SuggestAnswer(ctx context.Context, isANegativeComment bool, comment string,
 	return cleaned, nil
 }
 
+// generate sends a text prompt to model and records the call in the metrics
+// and in the audit log. operation names the kind of call (analysis,
+// generation, judge).
+func (c *Client) generate(ctx context.Context, model string, operation string, prompt string) (*genai.GenerateContentResponse, error) {
+	start := time.Now()
+	resp, err := c.gemini.Models.GenerateContent(ctx, model, genai.Text(prompt), nil)
+	latency := time.Since(start)
+	metrics.LLMCall(model, operation, latency, err)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("model", model))
+	audit(ctx, c.cfg.AuditPrompts, model, operation, prompt, resp, latency, err)
+	if err != nil {
+		logger.Warn("chamada ao Gemini falhou", logging.KeyCommentID, commentIDFrom(ctx), logging.KeyModel, model, "operation", operation, logging.KeyLatency, latency, logging.KeyError, err)
+	} else {
+		logger.Debug("chamada ao Gemini", logging.KeyCommentID, commentIDFrom(ctx), logging.KeyModel, model, "operation", operation, logging.KeyLatency, latency, "prompt_chars", len(prompt))
+	}
+	return resp, err
+}
+
 // getAnswerPrompt constructs the prompt for the LLM based on the comment and video context.
-func getPositiveAnswerPrompt(comment string, videoTitle string, videoDescription string, videoTranscript string, authorHistory []models.Comment, isMember bool, ragContext []string) string {
-	prompt := os.Getenv("PROMPT_POSITIVE_ANSWER")
+func getPositiveAnswerPrompt(prompt string, comment string, videoTitle string, videoDescription string, videoTranscript string, authorHistory []models.Comment, member *models.Member, ragContext []string) string {
 	if prompt == "" {
 		// Fallback removed for brevity in this tool call, but ideally keep a minimal default or just log/error
 		return "PROMPT_POSITIVE_ANSWER not set"
@@ -124,10 +182,7 @@ func getPositiveAnswerPrompt(comment string, videoTitle string, videoDescription
 		transcriptContext = fmt.Sprintf("\nTRANSCRIÇÃO DO VÍDEO: Use esta transcrição para entender o contexto do vídeo e dar uma resposta mais precisa:\n%s\n", videoTranscript)
 	}
 
-	var memberNotice string
-	if isMember {
-		memberNotice = "\nNote que este usuário é membro do canal, então seja um pouco mais caloroso e agradecido na resposta.\n"
-	}
+	memberNotice := getMemberNotice(member, "então seja um pouco mais caloroso e agradecido na resposta")
 
 	prompt = strings.ReplaceAll(prompt, "{{COMMENT}}", comment)
 	prompt = strings.ReplaceAll(prompt, "{{TITLE}}", videoTitle)
@@ -141,8 +196,7 @@ func getPositiveAnswerPrompt(comment string, videoTitle string, videoDescription
 }
 
 // getAnswerPrompt constructs the prompt for the LLM based on the comment and video context.
-func getNegativeAnswerPrompt(comment string, videoTitle string, videoDescription string, videoTranscript string, authorHistory []models.Comment, isMember bool, ragContext []string) string {
-	prompt := os.Getenv("PROMPT_NEGATIVE_ANSWER")
+func getNegativeAnswerPrompt(prompt string, comment string, videoTitle string, videoDescription string, videoTranscript string, authorHistory []models.Comment, member *models.Member, ragContext []string) string {
 	if prompt == "" {
 		return "PROMPT_NEGATIVE_ANSWER not set"
 	}
@@ -175,10 +229,7 @@ func getNegativeAnswerPrompt(comment string, videoTitle string, videoDescription
 		descriptionContext = fmt.Sprintf("\nDESCRIÇÃO DO VÍDEO: Use esta descrição para entender o contexto do vídeo e dar uma resposta mais precisa:\n%s\n", videoDescription)
 	}
 
-	var memberNotice string
-	if isMember {
-		memberNotice = "\nNote que este usuário é membro do canal, considere isso ao dar a resposta, agradecendo o apoio.\n"
-	}
+	memberNotice := getMemberNotice(member, "considere isso ao dar a resposta, agradecendo o apoio")
 
 	prompt = strings.ReplaceAll(prompt, "{{COMMENT}}", comment)
 	prompt = strings.ReplaceAll(prompt, "{{TITLE}}", videoTitle)
@@ -191,11 +242,71 @@ func getNegativeAnswerPrompt(comment string, videoTitle string, videoDescription
 	return prompt
 }
 
+// getMemberNotice tells the LLM the author is a member, with the level and
+// tenure when known, so a long-time top-tier member is thanked differently
+// from a new one. It returns "" for non-members (member == nil).
+func getMemberNotice(member *models.Member, instruction string) string {
+	if member == nil {
+		return ""
+	}
+	var details []string
+	if member.Level != "" {
+		details = append(details, fmt.Sprintf("nível %q", member.Level))
+	}
+	switch months := member.Months(time.Now()); {
+	case months < 0:
+	case months < 1:
+		details = append(details, "entrou há menos de um mês, então dê as boas-vindas")
+	case months < 12:
+		details = append(details, fmt.Sprintf("membro há %d %s", months, plural(months, "mês", "meses")))
+	default:
+		years := months / 12
+		details = append(details, fmt.Sprintf("membro há %d %s, reconheça o apoio de longa data", years, plural(years, "ano", "anos")))
+	}
+
+	notice := "\nNote que este usuário é membro do canal"
+	if len(details) > 0 {
+		notice += " (" + strings.Join(details, "; ") + ")"
+	}
+	return notice + ", " + instruction + ".\n"
+}
+
+// withLanguageNotice fills {{LANGUAGE}} with the instruction to answer in the
+// comment's language, or appends it when the prompt has no such placeholder.
+// Comments in Portuguese, the language of the prompts, or in an unknown
+// language need no instruction.
+func withLanguageNotice(prompt string, lang string) string {
+	var notice string
+	if lang != "" && lang != language.Portuguese {
+		name := language.Name(lang)
+		notice = fmt.Sprintf("\nIDIOMA: O comentário está em %s. Escreva a resposta em %s, mesmo que estas instruções, o histórico e os exemplos estejam em português.\n", name, name)
+	}
+	if strings.Contains(prompt, "{{LANGUAGE}}") {
+		return strings.ReplaceAll(prompt, "{{LANGUAGE}}", notice)
+	}
+	return prompt + notice
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
 // getAnalysisPrompt constructs a short prompt for the analysis model.
-func getAnalysisPrompt(comment string) string {
-	prompt := os.Getenv("PROMPT_ANALYSIS")
+func getAnalysisPrompt(prompt string, comment string) string {
 	if prompt == "" {
 		return "PROMPT_ANALYSIS not set"
 	}
 	return strings.ReplaceAll(prompt, "{{COMMENT}}", comment)
 }
+
+// stripCodeFence removes the markdown ```json fence some models wrap JSON in.
+func stripCodeFence(raw string) string {
+	cleaned := strings.TrimSpace(raw)
+	cleaned = strings.TrimPrefix(cleaned, "```json")
+	cleaned = strings.TrimPrefix(cleaned, "```")
+	cleaned = strings.TrimSuffix(cleaned, "```")
+	return strings.TrimSpace(cleaned)
+}
@@ -0,0 +1,230 @@
+// Package logging configures the structured loggers of the application. Each
+// subsystem gets its own *slog.Logger with a "subsystem" attribute, and the
+// level can be set per subsystem, e.g. LOG_LEVEL="info,llm=debug,youtube=warn".
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Subsystems.
+const (
+	App      = "app"
+	YouTube  = "youtube"
+	LLM      = "llm"
+	Database = "database"
+	Service  = "service"
+	UI       = "ui"
+	Webhook  = "webhook"
+	Web      = "web"
+	API      = "api"
+)
+
+// Attribute keys shared by every subsystem, so a comment can be followed
+// across the log whatever the subsystem that wrote the line.
+const (
+	KeyCommentID       = "comment_id"
+	KeyVideoID         = "video_id"
+	KeyAuthorChannelID = "author_channel_id"
+	KeyModel           = "model"
+	KeyLatency         = "latency"
+	KeyError           = "error"
+)
+
+// Options selects the level, format and destination of the logs. It is part
+// of the application configuration (the logging section).
+type Options struct {
+	// Level is a default level optionally followed by per-subsystem levels:
+	// "info" or "warn,llm=debug,service=debug".
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+	// Format is "text" (default) or "json".
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+	// File, when set, receives every log line; stderr then only shows
+	// warnings and errors, so the terminal UI stays readable.
+	File string `yaml:"file" env:"LOG_FILE"`
+}
+
+type config struct {
+	handler slog.Handler
+	level   slog.Level
+	levels  map[string]slog.Level
+}
+
+var (
+	current atomic.Pointer[config]
+
+	fileMu sync.Mutex
+	file   *os.File
+)
+
+func init() {
+	current.Store(&config{
+		handler: slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		level:   slog.LevelInfo,
+	})
+	slog.SetDefault(For(App))
+}
+
+// Setup applies opts to every logger, including the ones already returned by
+// For. It can be called again to change the configuration; the previous log
+// file is closed.
+func Setup(opts Options) error {
+	level, levels, err := ParseLevel(opts.Level)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stderr
+	var f *os.File
+	if opts.File != "" {
+		f, err = os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("logging: não foi possível abrir %s: %w", opts.File, err)
+		}
+		out = f
+	}
+
+	var handler slog.Handler
+	switch strings.ToLower(opts.Format) {
+	case "", "text":
+		handler = slog.NewTextHandler(out, &slog.HandlerOptions{Level: slog.LevelDebug})
+	case "json":
+		handler = slog.NewJSONHandler(out, &slog.HandlerOptions{Level: slog.LevelDebug})
+	default:
+		if f != nil {
+			f.Close()
+		}
+		return fmt.Errorf("logging: formato desconhecido %q (use text ou json)", opts.Format)
+	}
+	if f != nil {
+		stderr := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})
+		handler = teeHandler{handler, stderr}
+	}
+
+	current.Store(&config{handler: handler, level: level, levels: levels})
+
+	fileMu.Lock()
+	if file != nil {
+		file.Close()
+	}
+	file = f
+	fileMu.Unlock()
+	return nil
+}
+
+// Close closes the log file opened by Setup, if any.
+func Close() {
+	fileMu.Lock()
+	defer fileMu.Unlock()
+	if file != nil {
+		file.Close()
+		file = nil
+	}
+}
+
+// ParseLevel parses a level spec such as "info,llm=debug" into the default
+// level and the per-subsystem overrides. An empty spec means info.
+func ParseLevel(spec string) (slog.Level, map[string]slog.Level, error) {
+	level := slog.LevelInfo
+	levels := make(map[string]slog.Level)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		subsystem, name, found := strings.Cut(part, "=")
+		if !found {
+			name, subsystem = subsystem, ""
+		}
+		var l slog.Level
+		if err := l.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+			return 0, nil, fmt.Errorf("logging: nível inválido %q", part)
+		}
+		if subsystem == "" {
+			level = l
+		} else {
+			levels[strings.TrimSpace(subsystem)] = l
+		}
+	}
+	return level, levels, nil
+}
+
+// For returns the logger of a subsystem. It can be stored in a package
+// variable: the configuration is looked up on every call, so later Setup
+// calls apply to it.
+func For(subsystem string) *slog.Logger {
+	return slog.New(&subsystemHandler{subsystem: subsystem})
+}
+
+// subsystemHandler filters records by the subsystem level and forwards them to
+// the configured handler.
+type subsystemHandler struct {
+	subsystem string
+	// with replays WithAttrs/WithGroup calls on the current handler.
+	with []func(slog.Handler) slog.Handler
+}
+
+func (h *subsystemHandler) Enabled(_ context.Context, level slog.Level) bool {
+	cfg := current.Load()
+	min, ok := cfg.levels[h.subsystem]
+	if !ok {
+		min = cfg.level
+	}
+	return level >= min
+}
+
+func (h *subsystemHandler) Handle(ctx context.Context, r slog.Record) error {
+	handler := current.Load().handler.WithAttrs([]slog.Attr{slog.String("subsystem", h.subsystem)})
+	for _, w := range h.with {
+		handler = w(handler)
+	}
+	return handler.Handle(ctx, r)
+}
+
+func (h *subsystemHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.extend(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+}
+
+func (h *subsystemHandler) WithGroup(name string) slog.Handler {
+	return h.extend(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+func (h *subsystemHandler) extend(w func(slog.Handler) slog.Handler) slog.Handler {
+	with := make([]func(slog.Handler) slog.Handler, len(h.with), len(h.with)+1)
+	copy(with, h.with)
+	return &subsystemHandler{subsystem: h.subsystem, with: append(with, w)}
+}
+
+// teeHandler sends each record to both handlers, each applying its own level.
+type teeHandler [2]slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return t[0].Enabled(ctx, level) || t[1].Enabled(ctx, level)
+}
+
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var err error
+	for _, h := range t {
+		if h.Enabled(ctx, r.Level) {
+			if e := h.Handle(ctx, r.Clone()); e != nil {
+				err = e
+			}
+		}
+	}
+	return err
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return teeHandler{t[0].WithAttrs(attrs), t[1].WithAttrs(attrs)}
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	return teeHandler{t[0].WithGroup(name), t[1].WithGroup(name)}
+}
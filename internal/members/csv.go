@@ -0,0 +1,152 @@
+// Package members reads the channel members list exported from YouTube
+// Studio.
+package members
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"answer-comments/internal/models"
+)
+
+// columns holds the index of each recognized column, -1 when absent.
+type columns struct {
+	name, url, level, months, since int
+}
+
+// ParseCSV reads a members export. Columns are detected by header name, in
+// English or Portuguese ("Link to profile"/"Link para o perfil", "Current
+// level"/"Nível atual", "Total time as member (months)"/"Tempo total como
+// membro (meses)", "Member since"/"Membro desde"); files without a
+// recognizable header fall back to the old layout (profile link in the second
+// column) or, when the first row has a channel in the first column only, to a
+// list of links. When only the tenure in months is known, the membership start is
+// computed back from now. Rows without a channel are counted in skipped.
+func ParseCSV(r io.Reader, now time.Time) (members []models.Member, skipped int, err error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(records) == 0 {
+		return nil, 0, nil
+	}
+
+	cols, ok := detectColumns(records[0])
+	rows := records[1:]
+	if !ok {
+		cols = columns{name: 0, url: 1, level: -1, months: -1, since: -1}
+		// A list of bare links or IDs has them in the only column
+		if ChannelID(cell(records[0], 1)) == "" && ChannelID(cell(records[0], 0)) != "" {
+			cols = columns{name: -1, url: 0, level: -1, months: -1, since: -1}
+		}
+		// A file without a header starts with a member already.
+		if ChannelID(cell(records[0], cols.url)) != "" {
+			rows = records
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		id := ChannelID(cell(row, cols.url))
+		if id == "" {
+			skipped++
+			continue
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		m := models.Member{
+			ChannelID: id,
+			Name:      cell(row, cols.name),
+			Level:     cell(row, cols.level),
+		}
+		if s := cell(row, cols.since); s != "" {
+			m.Since = parseDate(s)
+		}
+		if m.Since.IsZero() {
+			if n, err := strconv.ParseFloat(strings.ReplaceAll(cell(row, cols.months), ",", "."), 64); err == nil && n >= 0 {
+				m.Since = now.AddDate(0, -int(n), 0)
+			}
+		}
+		members = append(members, m)
+	}
+	return members, skipped, nil
+}
+
+// ChannelID extracts the channel ID from a profile link
+// ("https://www.youtube.com/channel/UC...") or a bare ID. It returns "" when
+// s is neither.
+func ChannelID(s string) string {
+	s = strings.TrimSpace(s)
+	if _, after, found := strings.Cut(s, "/channel/"); found {
+		s = after
+		if i := strings.IndexAny(s, "/?#"); i >= 0 {
+			s = s[:i]
+		}
+	}
+	if len(s) == 24 && strings.HasPrefix(s, "UC") {
+		return s
+	}
+	return ""
+}
+
+func detectColumns(header []string) (columns, bool) {
+	cols := columns{name: -1, url: -1, level: -1, months: -1, since: -1}
+	for i, h := range header {
+		h = normalize(h)
+		has := func(words ...string) bool {
+			for _, w := range words {
+				if strings.Contains(h, w) {
+					return true
+				}
+			}
+			return false
+		}
+		switch {
+		case has("link", "profile", "perfil", "url"):
+			cols.url = i
+		case has("since", "desde"):
+			cols.since = i
+		case has("months", "meses") && has("member", "membro") && !has("level", "nivel"):
+			cols.months = i
+		case has("level", "nivel", "tier") && !has("time", "tempo", "months", "meses"):
+			cols.level = i
+		case h == "member" || h == "membro" || h == "name" || h == "nome" || h == "channel name":
+			cols.name = i
+		}
+	}
+	return cols, cols.url >= 0
+}
+
+var accents = strings.NewReplacer("á", "a", "â", "a", "ã", "a", "à", "a", "é", "e", "ê", "e", "í", "i", "ó", "o", "ô", "o", "õ", "o", "ú", "u", "ç", "c")
+
+func normalize(s string) string {
+	s = strings.TrimPrefix(s, "\ufeff")
+	return accents.Replace(strings.ToLower(strings.TrimSpace(s)))
+}
+
+func cell(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+var dateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02", "02/01/2006", "Jan 2, 2006"}
+
+func parseDate(s string) time.Time {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
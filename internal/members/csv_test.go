@@ -0,0 +1,121 @@
+package members
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"answer-comments/internal/models"
+)
+
+// Channel IDs used in the test exports.
+const (
+	ana   = "UCanaaaaaaaaaaaaaaaaaaaa"
+	bruno = "UCbrunoaaaaaaaaaaaaaaaaa"
+)
+
+func TestParseCSV(t *testing.T) {
+	exported := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	link := func(id string) string { return "https://www.youtube.com/channel/" + id }
+
+	tests := []struct {
+		name        string
+		csv         string
+		want        []models.Member
+		wantSkipped int
+	}{
+		{
+			name: "studio export in english",
+			csv: "Member,Link to profile,Current level,Total time on current level (months),Total time as member (months),Last update,Last update timestamp\n" +
+				"Ana," + link(ana) + ",Ouro,3,36,Joined,2026-10-01T00:00:00Z\n" +
+				"Bruno," + link(bruno) + ",Bronze,1,1,Joined,2026-10-01T00:00:00Z\n",
+			want: []models.Member{
+				{ChannelID: ana, Name: "Ana", Level: "Ouro", Since: exported.AddDate(0, -36, 0)},
+				{ChannelID: bruno, Name: "Bruno", Level: "Bronze", Since: exported.AddDate(0, -1, 0)},
+			},
+		},
+		{
+			name: "portuguese header with a start date, any column order",
+			csv: "\ufeffNível atual,Membro desde,Link para o perfil,Membro\n" +
+				"Prata,2024-05-10," + link(ana) + "/videos,Ana\n",
+			want: []models.Member{
+				{ChannelID: ana, Name: "Ana", Level: "Prata", Since: time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "headerless old layout",
+			csv:  "Ana," + link(ana) + "\nBruno," + link(bruno) + "\n",
+			want: []models.Member{
+				{ChannelID: ana, Name: "Ana"},
+				{ChannelID: bruno, Name: "Bruno"},
+			},
+		},
+		{
+			name: "headerless single column",
+			csv:  link(ana) + "\n" + bruno + "\n",
+			want: []models.Member{{ChannelID: ana}, {ChannelID: bruno}},
+		},
+		{
+			name:        "single-column rows in the old layout",
+			csv:         "Ana," + link(ana) + "\nBruno\n\n",
+			want:        []models.Member{{ChannelID: ana, Name: "Ana"}},
+			wantSkipped: 1,
+		},
+		{
+			name: "unknown and missing columns",
+			csv:  "Link to profile,Favorite color\n" + link(ana) + ",azul\n",
+			want: []models.Member{{ChannelID: ana}},
+		},
+		{
+			name:        "rows without a channel and duplicates",
+			csv:         "Member,Link to profile\nAna," + link(ana) + "\nSem link,\nLink ruim,https://www.youtube.com/@ana\nAna de novo," + link(ana) + "\n",
+			want:        []models.Member{{ChannelID: ana, Name: "Ana"}},
+			wantSkipped: 2,
+		},
+		{
+			name: "header without a link column",
+			csv:  "Member,Current level\nAna,Ouro\n",
+			// Read as the old layout: the header is dropped and the row has no channel
+			wantSkipped: 1,
+		},
+		{
+			name: "empty file",
+			csv:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, skipped, err := ParseCSV(strings.NewReader(tt.csv), exported)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if skipped != tt.wantSkipped {
+				t.Errorf("skipped = %d, want %d", skipped, tt.wantSkipped)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("%d membros, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("membro %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestChannelID(t *testing.T) {
+	tests := map[string]string{
+		"https://www.youtube.com/channel/" + ana:            ana,
+		"https://www.youtube.com/channel/" + ana + "?sub=1": ana,
+		" " + ana + " ":                ana,
+		"https://www.youtube.com/@ana": "",
+		"UCcurto":                      "",
+		"":                             "",
+	}
+	for in, want := range tests {
+		if got := ChannelID(in); got != want {
+			t.Errorf("ChannelID(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Package metrics keeps the process counters and histograms and serves them
+// in the Prometheus text exposition format.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Buckets, in seconds.
+var (
+	latencyBuckets     = []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 20, 60}
+	timeToReplyBuckets = []float64{
+		15 * 60, 3600, 3 * 3600, 6 * 3600, 12 * 3600,
+		24 * 3600, 2 * 24 * 3600, 7 * 24 * 3600, 30 * 24 * 3600,
+	}
+)
+
+var (
+	commentsFetched = newCounter("answer_comments_comments_fetched_total",
+		"Comentários não respondidos obtidos do YouTube.")
+	commentsAnalyzed = newCounter("answer_comments_comments_analyzed_total",
+		"Comentários analisados, por sentimento.", "sentiment")
+	repliesPublished = newCounter("answer_comments_replies_published_total",
+		"Respostas publicadas, por modo (auto, accepted, edited).", "mode")
+	commentsSkipped = newCounter("answer_comments_comments_skipped_total",
+		"Comentários deixados sem resposta, por motivo.", "reason")
+	llmCalls = newCounter("answer_comments_llm_calls_total",
+		"Chamadas ao Gemini, por modelo e operação.", "model", "operation")
+	llmErrors = newCounter("answer_comments_llm_errors_total",
+		"Chamadas ao Gemini que falharam, por modelo e operação.", "model", "operation")
+	llmLatency = newHistogram("answer_comments_llm_request_duration_seconds",
+		"Latência das chamadas ao Gemini.", latencyBuckets, "model", "operation")
+	youtubeCalls = newCounter("answer_comments_youtube_api_calls_total",
+		"Chamadas à YouTube Data API, por método.", "method")
+	youtubeErrors = newCounter("answer_comments_youtube_api_errors_total",
+		"Chamadas à YouTube Data API que falharam, por método.", "method")
+	youtubeQuota = newCounter("answer_comments_youtube_quota_units_total",
+		"Unidades de cota da YouTube Data API consumidas, por método.", "method")
+	timeToReply = newHistogram("answer_comments_time_to_reply_seconds",
+		"Tempo entre a publicação do comentário e a da resposta.", timeToReplyBuckets)
+)
+
+// CommentsFetched counts n unanswered comments fetched from YouTube.
+func CommentsFetched(n int) {
+	commentsFetched.add(float64(n))
+}
+
+// CommentAnalyzed counts an analysis.
+func CommentAnalyzed(sentiment string) {
+	commentsAnalyzed.add(1, sentiment)
+}
+
+// ReplyPublished counts a published reply and observes the time since the
+// comment was posted. A zero commentedAt skips the histogram.
+func ReplyPublished(mode string, commentedAt, publishedAt time.Time) {
+	repliesPublished.add(1, mode)
+	if !commentedAt.IsZero() {
+		timeToReply.observe(publishedAt.Sub(commentedAt).Seconds())
+	}
+}
+
+// CommentSkipped counts a comment left unanswered.
+func CommentSkipped(reason string) {
+	commentsSkipped.add(1, reason)
+}
+
+// LLMCall counts a Gemini call and observes its latency.
+func LLMCall(model, operation string, latency time.Duration, err error) {
+	llmCalls.add(1, model, operation)
+	llmLatency.observe(latency.Seconds(), model, operation)
+	if err != nil {
+		llmErrors.add(1, model, operation)
+	}
+}
+
+// YouTubeCall counts a YouTube Data API call and the quota units it costs.
+func YouTubeCall(method string, units int, err error) {
+	youtubeCalls.add(1, method)
+	youtubeQuota.add(float64(units), method)
+	if err != nil {
+		youtubeErrors.add(1, method)
+	}
+}
+
+// Handler serves every metric in the Prometheus text format.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		WriteTo(w)
+	})
+}
+
+// ListenAndServe serves /metrics on addr until ctx is canceled.
+func ListenAndServe(ctx context.Context, addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", Handler())
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// WriteTo writes every metric in the Prometheus text format.
+func WriteTo(w io.Writer) {
+	for _, m := range registry {
+		m.write(w)
+	}
+}
+
+// ── Implementation ────────────────────────────────────────────────────────────
+
+type metric interface {
+	write(w io.Writer)
+}
+
+var registry []metric
+
+type counter struct {
+	name   string
+	help   string
+	labels []string
+
+	mu     sync.Mutex
+	values map[string]float64 // key: label values joined by labelSep
+}
+
+type histogram struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	counts []uint64 // per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+const labelSep = "\xff"
+
+func newCounter(name, help string, labels ...string) *counter {
+	c := &counter{name: name, help: help, labels: labels, values: make(map[string]float64)}
+	registry = append(registry, c)
+	return c
+}
+
+func newHistogram(name, help string, buckets []float64, labels ...string) *histogram {
+	h := &histogram{name: name, help: help, labels: labels, buckets: buckets, series: make(map[string]*histogramSeries)}
+	registry = append(registry, h)
+	return h
+}
+
+func (c *counter) add(v float64, labelValues ...string) {
+	key := strings.Join(labelValues, labelSep)
+	c.mu.Lock()
+	c.values[key] += v
+	c.mu.Unlock()
+}
+
+func (h *histogram) observe(v float64, labelValues ...string) {
+	key := strings.Join(labelValues, labelSep)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s := h.series[key]
+	if s == nil {
+		s = &histogramSeries{counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+	for i, upper := range h.buckets {
+		if v <= upper {
+			s.counts[i]++
+			break
+		}
+	}
+	s.count++
+	s.sum += v
+}
+
+func (c *counter) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+	if len(c.labels) == 0 {
+		// Unlabeled counters are always exposed, starting at zero
+		fmt.Fprintf(w, "%s %s\n", c.name, formatValue(c.values[""]))
+		return
+	}
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, labelString(c.labels, key, ""), formatValue(c.values[key]))
+	}
+}
+
+func (h *histogram) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			le := `le="` + formatValue(upper) + `"`
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labelString(h.labels, key, le), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, labelString(h.labels, key, `le="+Inf"`), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, labelString(h.labels, key, ""), formatValue(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, labelString(h.labels, key, ""), s.count)
+	}
+}
+
+// labelString renders {name="value",...} for a series key, with an optional
+// extra pair (the histogram le label).
+func labelString(names []string, key string, extra string) string {
+	var pairs []string
+	if len(names) > 0 {
+		for i, v := range strings.Split(key, labelSep) {
+			pairs = append(pairs, names[i]+`="`+escapeLabel(v)+`"`)
+		}
+	}
+	if extra != "" {
+		pairs = append(pairs, extra)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+func formatValue(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
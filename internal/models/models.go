@@ -12,4 +12,31 @@ type SentimentAnalysis struct {
 	Sentimento string `json:"sentimento"`
 	Nota       int    `json:"nota"`
 	Tema       string `json:"tema"`
+	// Idioma is the ISO 639-1 code of the comment's language ("pt", "es",
+	// "en"), from the analysis JSON or detected locally; "" when unknown.
+	Idioma string `json:"idioma,omitempty"`
+}
+
+// Member is a channel member, as listed in the members export.
+type Member struct {
+	ChannelID string
+	Name      string
+	Level     string    // membership level (tier); empty when unknown
+	Since     time.Time // start of the membership; zero when unknown
+}
+
+// Months returns how many whole months the membership lasted until now, or -1
+// when the start is unknown.
+func (m Member) Months(now time.Time) int {
+	if m.Since.IsZero() {
+		return -1
+	}
+	months := (now.Year()-m.Since.Year())*12 + int(now.Month()-m.Since.Month())
+	if now.Day() < m.Since.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
 }
@@ -0,0 +1,133 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringService groups the application's entries in the OS keyring.
+const keyringService = "answer-comments"
+
+// The OS keyring is reached through its command-line tools: secret-tool
+// (libsecret) on Linux and security on macOS. Values are stored base64
+// encoded, since the tools handle text only, and are always passed on stdin:
+// arguments are visible to every user through ps.
+
+// errSecItemNotFound is the exit code of security when the entry does not
+// exist.
+const errSecItemNotFound = 44
+
+// KeyringSet stores value under name in the OS keyring, for settings
+// written as "keyring:<name>".
+func KeyringSet(name string, value []byte) error {
+	return keyringSet(name, value)
+}
+
+func keyringGet(account string) ([]byte, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.Command("secret-tool", "lookup", "service", keyringService, "account", account)
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", keyringService, "-a", account, "-w")
+	default:
+		return nil, errUnsupported()
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		if keyringNotFound(err) {
+			return nil, fmt.Errorf("%q não encontrado no chaveiro do sistema: %w", account, os.ErrNotExist)
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if msg := strings.TrimSpace(string(exitErr.Stderr)); msg != "" {
+				return nil, fmt.Errorf("erro ao acessar o chaveiro do sistema: %s: %w", msg, err)
+			}
+		}
+		return nil, fmt.Errorf("erro ao acessar o chaveiro do sistema: %w", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(out)))
+	if err != nil {
+		return nil, fmt.Errorf("entrada %q do chaveiro inválida: %w", account, err)
+	}
+	return data, nil
+}
+
+func keyringSet(account string, value []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(value)
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.Command("secret-tool", "store", "--label", keyringService+" "+account, "service", keyringService, "account", account)
+		cmd.Stdin = strings.NewReader(encoded)
+	case "darwin":
+		// With -w last and no value, security prompts for the password and
+		// its confirmation, read from stdin when it is not a terminal
+		cmd = exec.Command("security", "add-generic-password", "-U", "-s", keyringService, "-a", account, "-w")
+		cmd.Stdin = strings.NewReader(encoded + "\n" + encoded + "\n")
+	default:
+		return errUnsupported()
+	}
+	return runKeyring(cmd)
+}
+
+func keyringDelete(account string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd":
+		// secret-tool clear succeeds even when nothing matches
+		if _, err := keyringGet(account); err != nil {
+			return err
+		}
+		cmd = exec.Command("secret-tool", "clear", "service", keyringService, "account", account)
+	case "darwin":
+		cmd = exec.Command("security", "delete-generic-password", "-s", keyringService, "-a", account)
+	default:
+		return errUnsupported()
+	}
+	if err := runKeyring(cmd); err != nil {
+		// On Linux the lookup above already reported a missing entry
+		if runtime.GOOS == "darwin" && keyringNotFound(err) {
+			return fmt.Errorf("%w: %w", os.ErrNotExist, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// keyringNotFound reports whether err is the exit of a keyring tool that did
+// not find the entry. secret-tool lookup exits 1 without a message; it also
+// exits 1 on other failures (no Secret Service running, locked collection),
+// but then explains why on stderr. security has a dedicated exit code.
+func keyringNotFound(err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	if runtime.GOOS == "darwin" {
+		return exitErr.ExitCode() == errSecItemNotFound
+	}
+	return exitErr.ExitCode() == 1 && len(bytes.TrimSpace(exitErr.Stderr)) == 0
+}
+
+func runKeyring(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("erro no chaveiro do sistema: %s: %w", msg, err)
+		}
+		return fmt.Errorf("erro no chaveiro do sistema: %w", err)
+	}
+	return nil
+}
+
+func errUnsupported() error {
+	return fmt.Errorf("chaveiro do sistema não suportado em %s; use SECRETS_BACKEND=file", runtime.GOOS)
+}
@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeSecretTool puts a secret-tool running script first in PATH.
+func fakeSecretTool(t *testing.T, script string) {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("secret-tool só é usado no Linux")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "secret-tool"), []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestKeyringGet(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		want     string
+		notExist bool
+		errText  string
+	}{
+		{"found", "echo c2VncmVkbw==", "segredo", false, ""},
+		{"not found", "exit 1", "", true, ""},
+		{"service unavailable", "echo 'Cannot autolaunch D-Bus without X11' >&2; exit 1", "", false, "Cannot autolaunch D-Bus"},
+		{"crash", "exit 2", "", false, "exit status 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeSecretTool(t, tt.script)
+			got, err := keyringGet("TOKEN")
+			if tt.want != "" {
+				if err != nil || string(got) != tt.want {
+					t.Fatalf("keyringGet = %q, %v, want %q", got, err, tt.want)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("keyringGet não falhou")
+			}
+			if errors.Is(err, os.ErrNotExist) != tt.notExist {
+				t.Errorf("errors.Is(%v, os.ErrNotExist) = %v, want %v", err, !tt.notExist, tt.notExist)
+			}
+			if !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("erro %q não contém %q", err, tt.errText)
+			}
+		})
+	}
+}
+
+func TestKeyringSetUsesStdin(t *testing.T) {
+	args := filepath.Join(t.TempDir(), "args")
+	stdin := filepath.Join(t.TempDir(), "stdin")
+	fakeSecretTool(t, `echo "$@" > `+args+`; cat > `+stdin)
+
+	if err := keyringSet("TOKEN", []byte("segredo")); err != nil {
+		t.Fatal(err)
+	}
+	argv, err := os.ReadFile(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(argv), "c2VncmVkbw==") {
+		t.Errorf("o segredo aparece nos argumentos: %s", argv)
+	}
+	if in, _ := os.ReadFile(stdin); string(in) != "c2VncmVkbw==" {
+		t.Errorf("stdin = %q", in)
+	}
+}
@@ -0,0 +1,283 @@
+// Package secrets stores OAuth tokens and API keys encrypted at rest.
+//
+// Values are sealed with NaCl secretbox under a key derived with scrypt from a
+// passphrase (SECRETS_PASSPHRASE) or a key file (SECRETS_KEY_FILE), and
+// written as "enc:v1:<base64>". With SECRETS_BACKEND=keyring the token goes to
+// the OS keyring instead of a file. Settings can reference secrets as
+// "enc:v1:..." (encrypted inline) or "keyring:<name>" (see Value).
+package secrets
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Backends.
+const (
+	BackendFile    = "file"
+	BackendKeyring = "keyring"
+)
+
+const (
+	encPrefix     = "enc:v1:"
+	keyringPrefix = "keyring:"
+	saltSize      = 16
+	nonceSize     = 24
+	minKeyFile    = 32
+)
+
+// ErrNoKey is returned when an encrypted value is found but neither
+// SECRETS_PASSPHRASE nor SECRETS_KEY_FILE is set.
+var ErrNoKey = errors.New("segredo criptografado: defina SECRETS_PASSPHRASE ou SECRETS_KEY_FILE")
+
+// ErrDecrypt is returned when the passphrase or key file does not open a value.
+var ErrDecrypt = errors.New("não foi possível descriptografar o segredo (senha ou arquivo de chave incorreto?)")
+
+// Options configures where and how secrets are stored.
+type Options struct {
+	Backend    string `yaml:"backend" env:"SECRETS_BACKEND"` // BackendFile (default) or BackendKeyring
+	Passphrase string `yaml:"passphrase" env:"SECRETS_PASSPHRASE" secret:"true"`
+	KeyFile    string `yaml:"key_file" env:"SECRETS_KEY_FILE"`
+}
+
+var (
+	mu      sync.RWMutex
+	backend = BackendFile
+	master  []byte // nil = no encryption
+)
+
+// Setup applies opts to the package. Without a passphrase or key file, files
+// are written in plain text as before.
+func Setup(opts Options) error {
+	b := opts.Backend
+	switch b {
+	case "":
+		b = BackendFile
+	case BackendFile, BackendKeyring:
+	default:
+		return fmt.Errorf("SECRETS_BACKEND inválido %q (use file ou keyring)", b)
+	}
+
+	var key []byte
+	switch {
+	case opts.KeyFile != "":
+		data, err := os.ReadFile(opts.KeyFile)
+		if err != nil {
+			return fmt.Errorf("erro ao ler SECRETS_KEY_FILE: %w", err)
+		}
+		key = []byte(strings.TrimSpace(string(data)))
+		if len(key) < minKeyFile {
+			return fmt.Errorf("SECRETS_KEY_FILE precisa ter pelo menos %d bytes (gere com \"answer-comments secrets keygen\")", minKeyFile)
+		}
+	case opts.Passphrase != "":
+		key = []byte(opts.Passphrase)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	backend = b
+	master = key
+	return nil
+}
+
+// Encrypted reports whether new secrets are written encrypted.
+func Encrypted() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return master != nil
+}
+
+// Backend returns the configured backend.
+func Backend() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return backend
+}
+
+// IsReference reports whether s is an encrypted value or a keyring reference
+// that Value resolves.
+func IsReference(s string) bool {
+	return strings.HasPrefix(s, encPrefix) || strings.HasPrefix(s, keyringPrefix)
+}
+
+// Value resolves a setting: "enc:v1:..." is decrypted, "keyring:<name>" is
+// read from the OS keyring and anything else is returned unchanged.
+func Value(s string) (string, error) {
+	switch {
+	case strings.HasPrefix(s, encPrefix):
+		b, err := Decrypt(s)
+		return string(b), err
+	case strings.HasPrefix(s, keyringPrefix):
+		b, err := keyringGet(strings.TrimPrefix(s, keyringPrefix))
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	return s, nil
+}
+
+// Encrypt seals plaintext with the configured key.
+func Encrypt(plaintext []byte) (string, error) {
+	mu.RLock()
+	secret := master
+	mu.RUnlock()
+	if secret == nil {
+		return "", errors.New("criptografia desativada: defina SECRETS_PASSPHRASE ou SECRETS_KEY_FILE")
+	}
+
+	buf := make([]byte, saltSize+nonceSize, saltSize+nonceSize+len(plaintext)+secretbox.Overhead)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	key, err := deriveKey(secret, buf[:saltSize])
+	if err != nil {
+		return "", err
+	}
+	var nonce [nonceSize]byte
+	copy(nonce[:], buf[saltSize:])
+	sealed := secretbox.Seal(buf, plaintext, &nonce, key)
+	return encPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value produced by Encrypt.
+func Decrypt(s string) ([]byte, error) {
+	mu.RLock()
+	secret := master
+	mu.RUnlock()
+	if secret == nil {
+		return nil, ErrNoKey
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(strings.TrimSpace(s), encPrefix))
+	if err != nil || len(data) < saltSize+nonceSize+secretbox.Overhead {
+		return nil, errors.New("valor criptografado malformado")
+	}
+	key, err := deriveKey(secret, data[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	var nonce [nonceSize]byte
+	copy(nonce[:], data[saltSize:saltSize+nonceSize])
+	plaintext, ok := secretbox.Open(nil, data[saltSize+nonceSize:], &nonce, key)
+	if !ok {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+func deriveKey(secret, salt []byte) (*[32]byte, error) {
+	k, err := scrypt.Key(secret, salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	var key [32]byte
+	copy(key[:], k)
+	return &key, nil
+}
+
+// ReadFile reads a secret saved by WriteFile. Plain-text files written before
+// encryption was enabled are still read, and get encrypted on the next write.
+// With the keyring backend the file is used only when the keyring has no entry
+// yet. A missing secret wraps os.ErrNotExist.
+func ReadFile(path string) ([]byte, error) {
+	if Backend() == BackendKeyring {
+		data, err := keyringGet(fileAccount(path))
+		if err == nil || !errors.Is(err, os.ErrNotExist) {
+			return data, err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if s := strings.TrimSpace(string(data)); strings.HasPrefix(s, encPrefix) {
+		return Decrypt(s)
+	}
+	return data, nil
+}
+
+// WriteFile saves a secret. With the file backend, path is replaced
+// atomically (temporary file + rename, mode 0600), encrypted when a key is
+// configured; with the keyring backend the secret goes to the keyring and a
+// leftover file at path is removed.
+func WriteFile(path string, data []byte) error {
+	if Backend() == BackendKeyring {
+		if err := keyringSet(fileAccount(path), data); err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	if Encrypted() {
+		s, err := Encrypt(data)
+		if err != nil {
+			return err
+		}
+		data = []byte(s + "\n")
+	}
+	return writeAtomic(path, data)
+}
+
+// Remove deletes a secret saved by WriteFile from the keyring and from disk.
+func Remove(path string) error {
+	found := false
+	if Backend() == BackendKeyring {
+		switch err := keyringDelete(fileAccount(path)); {
+		case err == nil:
+			found = true
+		case !errors.Is(err, os.ErrNotExist):
+			return err
+		}
+	}
+	err := os.Remove(path)
+	if found && errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// fileAccount is the keyring entry that replaces the file at path.
+func fileAccount(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return "file:" + path
+}
+
+// writeAtomic replaces path atomically: data is written to a temporary file
+// in the same directory and renamed over it, so a crash never leaves a
+// truncated file behind.
+func writeAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".secret-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // no-op after the rename
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,177 @@
+package secrets_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"answer-comments/internal/secrets"
+	yt "answer-comments/internal/youtube"
+
+	"golang.org/x/oauth2"
+)
+
+// setup applies opts for the duration of the test.
+func setup(t *testing.T, opts secrets.Options) {
+	t.Helper()
+	if err := secrets.Setup(opts); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { secrets.Setup(secrets.Options{}) })
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	setup(t, secrets.Options{Passphrase: "senha longa"})
+
+	sealed, err := secrets.Encrypt([]byte("chave-do-gemini"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sealed, "enc:v1:") || strings.Contains(sealed, "chave-do-gemini") {
+		t.Fatalf("valor selado = %q", sealed)
+	}
+	again, err := secrets.Encrypt([]byte("chave-do-gemini"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again == sealed {
+		t.Error("dois valores selados iguais: o salt e o nonce devem ser aleatórios")
+	}
+
+	for _, s := range []string{sealed, again} {
+		if got, err := secrets.Value(s); err != nil || got != "chave-do-gemini" {
+			t.Errorf("Value = %q, %v", got, err)
+		}
+	}
+	if got, err := secrets.Value("texto puro"); err != nil || got != "texto puro" {
+		t.Errorf("Value(texto puro) = %q, %v", got, err)
+	}
+}
+
+func TestDecryptFailures(t *testing.T) {
+	setup(t, secrets.Options{Passphrase: "senha certa"})
+	sealed, err := secrets.Encrypt([]byte("segredo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A truncated value fails authentication like a wrong key
+	if _, err := secrets.Decrypt(sealed[:len(sealed)-4]); !errors.Is(err, secrets.ErrDecrypt) {
+		t.Errorf("valor truncado: erro = %v, want ErrDecrypt", err)
+	}
+	if _, err := secrets.Decrypt("enc:v1:curto"); err == nil {
+		t.Error("valor malformado aceito")
+	}
+
+	setup(t, secrets.Options{Passphrase: "senha errada"})
+	if _, err := secrets.Decrypt(sealed); !errors.Is(err, secrets.ErrDecrypt) {
+		t.Errorf("senha errada: erro = %v, want ErrDecrypt", err)
+	}
+
+	setup(t, secrets.Options{})
+	if _, err := secrets.Decrypt(sealed); !errors.Is(err, secrets.ErrNoKey) {
+		t.Errorf("sem chave: erro = %v, want ErrNoKey", err)
+	}
+	if _, err := secrets.Encrypt([]byte("segredo")); err == nil {
+		t.Error("Encrypt sem chave não falhou")
+	}
+}
+
+func TestKeyFile(t *testing.T) {
+	dir := t.TempDir()
+	short := filepath.Join(dir, "curta.key")
+	if err := os.WriteFile(short, []byte("curta\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := secrets.Setup(secrets.Options{KeyFile: short}); err == nil {
+		t.Error("arquivo de chave curto aceito")
+	}
+
+	key := filepath.Join(dir, "secrets.key")
+	if err := os.WriteFile(key, []byte(strings.Repeat("k", 44)+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	setup(t, secrets.Options{KeyFile: key})
+	sealed, err := secrets.Encrypt([]byte("segredo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The key file wins over the passphrase and its trailing newline is ignored
+	setup(t, secrets.Options{KeyFile: key, Passphrase: "outra"})
+	if got, err := secrets.Decrypt(sealed); err != nil || string(got) != "segredo" {
+		t.Errorf("Decrypt = %q, %v", got, err)
+	}
+}
+
+func TestReadFilePlainText(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	if err := os.WriteFile(path, []byte(`{"access_token":"abc"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// A file written before encryption was enabled is still read
+	setup(t, secrets.Options{Passphrase: "senha"})
+	got, err := secrets.ReadFile(path)
+	if err != nil || string(got) != `{"access_token":"abc"}` {
+		t.Fatalf("ReadFile = %q, %v", got, err)
+	}
+
+	if _, err := secrets.ReadFile(filepath.Join(t.TempDir(), "ausente.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("arquivo ausente: erro = %v, want os.ErrNotExist", err)
+	}
+}
+
+// TestSaveTokenMigration covers "secrets migrate": a token saved in plain
+// text is rewritten encrypted by SaveToken once a key is configured.
+func TestSaveTokenMigration(t *testing.T) {
+	tokFile := filepath.Join(t.TempDir(), "token.json")
+	tok := &oauth2.Token{
+		AccessToken:  "ya29.acesso",
+		RefreshToken: "1//renovacao",
+		TokenType:    "Bearer",
+		Expiry:       time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC),
+	}
+	if err := yt.SaveToken(tokFile, tok); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(tokFile); !strings.Contains(string(data), "1//renovacao") {
+		t.Fatalf("token sem criptografia esperado em texto puro: %s", data)
+	}
+
+	setup(t, secrets.Options{Passphrase: "senha"})
+	loaded, err := yt.LoadToken(tokFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := yt.SaveToken(tokFile, loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(tokFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "enc:v1:") || strings.Contains(string(data), "1//renovacao") {
+		t.Errorf("token não criptografado após a migração: %s", data)
+	}
+	if info, err := os.Stat(tokFile); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("permissões do token = %v, %v", info.Mode().Perm(), err)
+	}
+
+	migrated, err := yt.LoadToken(tokFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrated.AccessToken != tok.AccessToken || migrated.RefreshToken != tok.RefreshToken || !migrated.Expiry.Equal(tok.Expiry) {
+		t.Errorf("token migrado = %+v, want %+v", migrated, tok)
+	}
+
+	// Without the key the migrated token cannot be read
+	setup(t, secrets.Options{})
+	if _, err := yt.LoadToken(tokFile); !errors.Is(err, secrets.ErrNoKey) {
+		t.Errorf("sem chave: erro = %v, want ErrNoKey", err)
+	}
+}
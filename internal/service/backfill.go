@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"answer-comments/internal/database"
+	"answer-comments/internal/language"
+	"answer-comments/internal/llm"
+	"answer-comments/internal/logging"
+	yt "answer-comments/internal/youtube"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// backfillPageSize is the largest page commentThreads.list accepts.
+const backfillPageSize = 100
+
+// BackfillOptions configures Backfill.
+type BackfillOptions struct {
+	// Analyze labels each imported comment with AnalyzeComment (sentiment,
+	// score and theme). Without it those fields are left empty.
+	Analyze bool
+	// QuotaBudget is the most YouTube quota units the run may spend.
+	QuotaBudget int
+	// Restart ignores the progress saved by an interrupted run.
+	Restart bool
+}
+
+// BackfillResult summarizes a backfill run.
+type BackfillResult struct {
+	Pages     int // pages fetched in this run
+	Answered  int // threads the channel had replied to
+	Imported  int
+	Existing  int // already in the history
+	Analyzed  int
+	QuotaUsed int
+	// Resumed is true when the run continued from saved progress.
+	Resumed bool
+	// Done is true when the last page was reached; otherwise the next run
+	// resumes where this one stopped.
+	Done bool
+}
+
+// Backfill imports into the local history the comments the channel already
+// replied to outside this tool (e.g. in YouTube Studio), so author history
+// and past answers cover them too. It pages through every thread of the
+// channel until the last page, the quota budget or ctx ends; the position is
+// saved after each page. progress, when not nil, is called after each page.
+func (s *CommentService) Backfill(ctx context.Context, opts BackfillOptions, progress func(BackfillResult)) (BackfillResult, error) {
+	var res BackfillResult
+	channelID := s.App.ChannelID
+
+	if opts.Restart {
+		if err := database.DeleteBackfillState(channelID); err != nil {
+			return res, err
+		}
+	}
+	state, ok, err := database.GetBackfillState(channelID)
+	if err != nil {
+		return res, err
+	}
+	res.Resumed = ok
+	if !ok {
+		state = database.BackfillState{ChannelID: channelID}
+	}
+
+	pageCost := yt.QuotaCost("commentThreads.list")
+	for {
+		if ctx.Err() != nil {
+			return res, nil
+		}
+		if res.QuotaUsed+pageCost > opts.QuotaBudget {
+			logger.Info("backfill interrompido: orçamento de cota atingido", "quota_used", res.QuotaUsed, "budget", opts.QuotaBudget)
+			return res, nil
+		}
+
+		response, err := s.App.YT.ListThreads(ctx, channelID, state.PageToken, backfillPageSize)
+		res.QuotaUsed += pageCost
+		if err != nil {
+			if ctx.Err() != nil {
+				return res, nil
+			}
+			return res, fmt.Errorf("erro ao buscar os comentários: %w", classifyYouTubeError(err))
+		}
+		res.Pages++
+
+		for _, thread := range response.Items {
+			reply := channelReply(thread, channelID)
+			if reply == nil {
+				continue
+			}
+			res.Answered++
+			imported, err := s.backfillThread(ctx, thread.Snippet.TopLevelComment, reply, opts.Analyze, &res)
+			if err != nil {
+				return res, err
+			}
+			if imported {
+				res.Imported++
+				state.Imported++
+			} else {
+				res.Existing++
+			}
+		}
+
+		state.Pages++
+		state.PageToken = response.NextPageToken
+		state.UpdatedAt = time.Now()
+		if state.PageToken == "" {
+			res.Done = true
+			if err := database.DeleteBackfillState(channelID); err != nil {
+				return res, err
+			}
+		} else if err := database.SaveBackfillState(state); err != nil {
+			return res, err
+		}
+		if progress != nil {
+			progress(res)
+		}
+		if res.Done {
+			return res, nil
+		}
+	}
+}
+
+// backfillThread stores one comment and the channel's reply. imported is false
+// when the comment was already in the history.
+func (s *CommentService) backfillThread(ctx context.Context, comment, reply *youtube.Comment, analyze bool, res *BackfillResult) (imported bool, err error) {
+	exists, err := database.HasComment(comment.Id)
+	if err != nil || exists {
+		return false, err
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, comment.Snippet.PublishedAt)
+	respondedAt, _ := time.Parse(time.RFC3339, reply.Snippet.PublishedAt)
+	c := database.DBComment{
+		ID:          comment.Id,
+		Author:      comment.Snippet.AuthorDisplayName,
+		CommentText: comment.Snippet.TextOriginal,
+		Response:    reply.Snippet.TextOriginal,
+		// Replies written by hand are the reference answers for future
+		// suggestions, like the ones edited in the review session.
+		UserAnswered: true,
+		CreatedAt:    createdAt,
+		RespondedAt:  respondedAt,
+		VideoID:      comment.Snippet.VideoId,
+		Language:     language.Detect(comment.Snippet.TextOriginal),
+	}
+	if comment.Snippet.AuthorChannelId != nil {
+		c.AuthorChannelID = comment.Snippet.AuthorChannelId.Value
+	}
+
+	if analyze {
+		sentiment, err := s.App.LLM.AnalyzeComment(llm.WithCommentID(ctx, comment.Id), comment.Snippet.TextOriginal)
+		if err != nil {
+			err = classifyLLMError(err)
+			if isFatal(err) {
+				return false, fmt.Errorf("erro na análise de sentimento: %w", err)
+			}
+			logger.Warn("análise falhou; importando sem rótulos", append(commentAttrs(comment), logging.KeyError, err)...)
+		} else {
+			c.Sentiment, c.Score, c.Theme, c.Language = sentiment.Sentimento, sentiment.Nota, sentiment.Tema, sentiment.Idioma
+			res.Analyzed++
+		}
+	}
+
+	if _, err := database.ImportComments([]database.DBComment{c}, database.ConflictSkip, false); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// channelReply returns the channel's first reply in the thread, or nil. Only
+// the replies embedded in the thread are checked (the API returns up to five).
+func channelReply(thread *youtube.CommentThread, channelID string) *youtube.Comment {
+	if thread.Replies == nil {
+		return nil
+	}
+	var first *youtube.Comment
+	for _, reply := range thread.Replies.Comments {
+		if reply.Snippet.AuthorChannelId == nil || reply.Snippet.AuthorChannelId.Value != channelID {
+			continue
+		}
+		if first == nil || reply.Snippet.PublishedAt < first.Snippet.PublishedAt {
+			first = reply
+		}
+	}
+	return first
+}
@@ -1,377 +1,366 @@
 package service
 
 import (
-	"bufio"
 	"context"
-	"encoding/csv"
 	"errors"
 	"fmt"
-	"log"
-	"net"
-	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"answer-comments/internal/app"
-	"answer-comments/internal/database"
-	"answer-comments/internal/debuglog"
-	"answer-comments/internal/llm"
+	"answer-comments/internal/clock"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/input"
+	"answer-comments/internal/logging"
 	"answer-comments/internal/models"
+	"answer-comments/internal/tracing"
 	"answer-comments/internal/ui"
-	yt "answer-comments/internal/youtube"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/youtube/v3"
 )
 
+var logger = logging.For(logging.Service)
+
 type CommentService struct {
 	App *app.App
+	// Input is where the reviewer's answers come from (stdin by default).
+	Input input.Source
+	// Clock drives the auto-answer countdowns (wall clock by default).
+	Clock clock.Clock
+
+	membersLoad sync.Mutex // serializes LoadMembers
+
+	mu            sync.Mutex
+	members       map[string]models.Member // by author channel ID
+	membersLoaded time.Time                // when members was last loaded
+	seen          map[string]bool          // comments already reported by comment.received
+	skipped       map[string]bool          // comments skipped in this process, ignored by unattended passes
 }
 
 func NewCommentService(a *app.App) *CommentService {
-	return &CommentService{App: a}
+	return &CommentService{App: a, Input: input.Stdin(), Clock: clock.Real{}}
 }
 
 type AnswerOptions struct {
 	ManualMode        bool
 	AutoAnswerMode    bool
 	TranscriptionMode bool
+	// Unattended runs without a reviewer: no prompts and no countdowns. The
+	// answers ShouldAutoPublish accepts are published, every other comment is
+	// skipped, and the pass ends after the last page. Implies AutoAnswerMode.
+	Unattended bool
 }
 
+// ProcessComments runs the review session. It returns nil when every comment
+// was reviewed, ErrUserQuit when the reviewer quits, and an error wrapping
+// ErrNetwork, ErrQuotaExceeded or ErrLLMUnavailable when the session cannot
+// go on.
 func (s *CommentService) ProcessComments(ctx context.Context, opts AnswerOptions) error {
-	// load members from CSV
-	membersMap, err := s.loadMembersFromCSV(s.App.Config.MembersCSVFile)
-	if err != nil {
-		log.Printf("Não foi possível carregar a lista de membros: %v", err)
+	s.LoadMembers(ctx)
+	ui.Success(i18n.T("review.members_loaded", s.MemberCount()))
+
+	if opts.Unattended {
+		opts.AutoAnswerMode = true
+		opts.ManualMode = false
 	}
-	ui.Success(fmt.Sprintf("Carregados %d membros a partir do arquivo.", len(membersMap)))
 
-	reader := bufio.NewReader(os.Stdin)
 	var pageToken string
 
-	// Goroutine permanente de leitura de stdin para AutoAnswerMode.
-	// Evita o goroutine leak que ocorria quando Countdown expirava e deixava
-	// uma goroutine bloqueada em ReadString, roubando Enters subsequentes.
-	var stdinCh chan string
-	if opts.AutoAnswerMode {
-		stdinCh = make(chan string, 1)
-		go func() {
-			for {
-				line, _ := reader.ReadString('\n')
-				debuglog.Log("[stdin] leu linha: %q", line)
-				stdinCh <- line
-				debuglog.Log("[stdin] entregue ao channel")
-			}
-		}()
-	}
-
-	fmt.Println()
-	fmt.Printf("  %s→ Pressione Enter para iniciar a verificação de comentários...%s ", ui.FgBrightCyan+ui.Bold, ui.Reset)
-	if opts.AutoAnswerMode {
-		<-stdinCh
-	} else {
-		_, _ = reader.ReadString('\n')
+	if !opts.Unattended {
+		fmt.Println()
+		fmt.Printf("  %s→ %s%s ", ui.FgBrightCyan+ui.Bold, i18n.T("review.press_enter_to_start"), ui.Reset)
+		if _, ok := s.readLine(); !ok {
+			return ErrUserQuit
+		}
 	}
 
 	for {
 		ui.PrintSearchingBanner()
 
-		call := s.App.YTService.CommentThreads.List([]string{"snippet,replies"}).
-			AllThreadsRelatedToChannelId(s.App.ChannelID).
-			Order("time").
-			PageToken(pageToken).
-			MaxResults(25)
-
-		response, err := call.Do()
+		comments, nextPageToken, err := s.ListUnanswered(ctx, pageToken)
 		if err != nil {
-			return fmt.Errorf("erro ao buscar os comentários: %w", err)
+			return err
+		}
+		pageToken = nextPageToken
+		if opts.Unattended {
+			comments = s.notSkipped(comments)
 		}
 
-		pageToken = response.NextPageToken
-		foundUnanswered := false
-
-		for _, item := range response.Items {
-			comment := item.Snippet.TopLevelComment
-			commentPublishedAt, _ := time.Parse(time.RFC3339, comment.Snippet.PublishedAt)
-
-			isAnsweredByMe := false
-			if item.Replies != nil {
-				for _, reply := range item.Replies.Comments {
-					if reply.Snippet.AuthorChannelId.Value == s.App.ChannelID {
-						isAnsweredByMe = true
-						break
-					}
+		for _, comment := range comments {
+			if err := s.processComment(ctx, comment, opts); err != nil {
+				if isFatal(err) {
+					logger.Debug("erro fatal, abortando a sessão", append(commentAttrs(comment), logging.KeyError, err)...)
+					return err
 				}
+				logger.Error("erro ao processar comentário", append(commentAttrs(comment), logging.KeyError, err)...)
 			}
+		}
 
-			if !isAnsweredByMe {
-				foundUnanswered = true
-				if err := s.handleUnansweredComment(ctx, comment, commentPublishedAt, membersMap, opts, reader, stdinCh); err != nil {
-					if isNetworkError(err) {
-						debuglog.Log("[network] erro de rede, abortando: %v", err)
-						log.Printf("Erro de rede — abortando: %v", err)
-						os.Exit(-1)
-					}
-					log.Printf("Erro ao processar comentário %s: %v", comment.Id, err)
-				}
-			}
+		if opts.Unattended && pageToken == "" {
+			fmt.Println()
+			ui.Info(i18n.T("review.all_pages_checked"))
+			return nil
 		}
 
-		if !foundUnanswered {
+		if len(comments) == 0 {
 			if pageToken == "" {
 				fmt.Println()
-				ui.Info("Não há mais comentários não respondidos em todas as páginas disponíveis.")
+				ui.Info(i18n.T("review.no_more_comments"))
 				return nil
-			} else {
+			} else if !opts.Unattended {
 				fmt.Println()
-				ui.Info("Não há mais comentários não respondidos neste lote.")
+				ui.Info(i18n.T("review.no_more_in_batch"))
 				ui.PrintDivider()
-				fmt.Printf("\n  %s→ Pressione Enter para buscar o próximo lote, ou digite Q para sair: %s", ui.FgBrightCyan+ui.Bold, ui.Reset)
-				debuglog.Log("[próximo-lote] aguardando input autoMode=%v", opts.AutoAnswerMode)
-				var input string
-				if opts.AutoAnswerMode {
-					input = strings.TrimSpace(strings.ToUpper(<-stdinCh))
-				} else {
-					line, _ := reader.ReadString('\n')
-					input = strings.TrimSpace(strings.ToUpper(line))
-				}
-				debuglog.Log("[próximo-lote] input=%q", input)
-				if input == "Q" {
-					return nil
+				fmt.Printf("\n  %s→ %s%s", ui.FgBrightCyan+ui.Bold, i18n.T("review.next_batch", ui.Key(ui.ActionQuit)), ui.Reset)
+				logger.Debug("aguardando confirmação do próximo lote", "auto_mode", opts.AutoAnswerMode)
+				line, ok := s.readLine()
+				if !ok || ui.ParseAction(line) == ui.ActionQuit {
+					return ErrUserQuit
 				}
 			}
 		}
 	}
 }
 
-func (s *CommentService) handleUnansweredComment(ctx context.Context, comment *youtube.Comment, publishedAt time.Time, membersMap map[string]bool, opts AnswerOptions, reader *bufio.Reader, stdinCh chan string) error {
-	debuglog.Log("[comment] início — id=%s autor=%q", comment.Id, comment.Snippet.AuthorDisplayName)
-	isMember := membersMap["https://www.youtube.com/channel/"+comment.Snippet.AuthorChannelId.Value]
-
-	videoCall := s.App.YTService.Videos.List([]string{"snippet"}).Id(comment.Snippet.VideoId)
-	videoResp, err := videoCall.Do()
-	videoTitle := "[Não foi possível obter o título]"
-	videoDescription := "[Não foi possível obter a descrição]"
-	if err == nil && len(videoResp.Items) > 0 {
-		videoTitle = videoResp.Items[0].Snippet.Title
-		videoDescription = videoResp.Items[0].Snippet.Description
+// processComment handles one comment inside its own trace.
+func (s *CommentService) processComment(ctx context.Context, comment *youtube.Comment, opts AnswerOptions) error {
+	ctx, span := tracing.StartComment(ctx, comment.Id, comment.Snippet.VideoId)
+	err := s.handleUnansweredComment(ctx, comment, opts)
+	if errors.Is(err, ErrUserQuit) {
+		tracing.End(span, nil)
+	} else {
+		tracing.End(span, err)
 	}
+	return err
+}
+
+func (s *CommentService) handleUnansweredComment(ctx context.Context, comment *youtube.Comment, opts AnswerOptions) error {
+	logger.Debug("início do comentário", append(commentAttrs(comment), "author", comment.Snippet.AuthorDisplayName)...)
+	pc := s.Prepare(ctx, comment)
 
 	// ── Header ────────────────────────────────────────────────────────────────
 	ui.ClearScreen()
-	ui.PrintHeader("Novo comentário não respondido encontrado")
+	ui.PrintHeader(i18n.T("review.header"))
 
 	// ── Comment Details ───────────────────────────────────────────────────────
-	brTime := publishedAt.In(time.FixedZone("BRT", -3*60*60))
+	brTime := pc.PublishedAt.In(time.FixedZone("BRT", -3*60*60))
 
 	authorLine := comment.Snippet.AuthorDisplayName
-	if isMember {
+	if pc.IsMember {
 		authorLine = ui.MemberBadge() + authorLine
+		if pc.Member.Level != "" {
+			authorLine += " · " + pc.Member.Level
+		}
 	}
 
-	ui.PrintCommentMeta(videoTitle, authorLine, brTime.Format("02/01/2006 às 15:04"))
+	ui.PrintCommentMeta(pc.VideoTitle, authorLine, brTime.Format(i18n.T("review.date_layout")))
 	ui.PrintComment(comment.Snippet.TextDisplay)
 
 	// ── Sentiment Analysis ────────────────────────────────────────────────────
-	ui.PrintSectionTitle("Análise do comentário")
+	ui.PrintSectionTitle(i18n.T("review.section.analysis"))
 
-	sentiment, err := llm.AnalyzeComment(ctx, comment.Snippet.TextOriginal, s.App.GeminiClient)
+	sentiment, err := s.Analyze(ctx, pc)
 	if err != nil {
-		return fmt.Errorf("erro na análise de sentimento: %w", err)
+		return err
 	}
 
-	fmt.Printf("  %s  %s  %s\n",
+	fmt.Printf("  %s  %s  %s  %s\n",
 		ui.SentimentBadge(sentiment.Sentimento),
 		ui.NotaBadge(sentiment.Nota),
 		ui.ThemeBadge(sentiment.Tema),
+		ui.LanguageBadge(sentiment.Idioma),
 	)
 
-	var answer, suggestedAnswer, input string
+	var answer, suggestedAnswer string
+	action := ui.ActionNone
+	publishMode := PublishAccepted
 	if opts.ManualMode {
-		input = "E"
-	}
-
-	pastAnswers, err := database.GetPreviousAnswersByContext(sentiment.Tema, sentiment.Sentimento, 5)
-	if err != nil {
-		log.Printf("Erro ao buscar histórico de RAG: %v", err)
+		action = ui.ActionEdit
 	}
 
-	authorHistory, err := database.GetLastComments(comment.Snippet.AuthorDisplayName, 10)
-	if err != nil {
-		log.Printf("Erro ao buscar histórico de comentários: %v", err)
-	}
-
-	shouldSuggestAnswer := !opts.ManualMode && sentiment.Sentimento != "negativo" && sentiment.Nota >= 3
-	debuglog.Log("[comment] sentimento=%s nota=%d tema=%q shouldSuggest=%v manualMode=%v autoMode=%v",
-		sentiment.Sentimento, sentiment.Nota, sentiment.Tema, shouldSuggestAnswer, opts.ManualMode, opts.AutoAnswerMode)
+	shouldSuggestAnswer := !opts.ManualMode && s.ShouldSuggest(sentiment)
+	logger.Debug("comentário analisado", append(commentAttrs(comment),
+		"sentiment", sentiment.Sentimento, "score", sentiment.Nota, "theme", sentiment.Tema, "language", sentiment.Idioma,
+		"should_suggest", shouldSuggestAnswer, "manual_mode", opts.ManualMode, "auto_mode", opts.AutoAnswerMode)...)
 	if shouldSuggestAnswer {
-		var videoTranscript string
-		transcriptLen := 0 // 0 = not fetched, -1 = error, >0 = char count
-		if opts.TranscriptionMode && sentiment.Tema != "Saudação/Agradecimento" {
-			videoTranscript, err = yt.GetVideoTranscription(ctx, s.App.YTService, comment.Snippet.VideoId)
-			if err != nil {
-				log.Printf("Não foi possível obter a transcrição: %v", err)
-				transcriptLen = -1
-			} else {
-				transcriptLen = len(videoTranscript)
+		sug, err := s.Suggest(ctx, pc, sentiment, opts.TranscriptionMode)
+		if err != nil {
+			// No modo auto-resposta não há ninguém olhando: qualquer falha de geração encerra a sessão
+			if opts.AutoAnswerMode && !isFatal(err) {
+				err = fmt.Errorf("%w: %w", ErrLLMUnavailable, err)
 			}
+			return err
 		}
 
-		ui.PrintSectionTitle("Contexto")
-		ui.PrintContextBar(transcriptLen, len(authorHistory), len(pastAnswers))
+		ui.PrintSectionTitle(i18n.T("review.section.context"))
+		ui.PrintContextBar(sug.TranscriptLen, sug.AuthorHistory, sug.PastAnswers)
 
-		ui.PrintSectionTitle("Sugestão de resposta")
-		suggestedAnswer, err = llm.SuggestAnswer(ctx, sentiment.Sentimento == "negativo", comment.Snippet.TextOriginal, videoTitle, videoDescription, videoTranscript, authorHistory, isMember, pastAnswers, s.App.GeminiClient)
-
-		if err != nil { //TODO: fazer o if do auto-answer aqui para sair da app quando der erro
-			if opts.AutoAnswerMode {
-				os.Exit(-1)
-			}
-			return fmt.Errorf("erro ao sugerir resposta: %w", err)
-		}
+		ui.PrintSectionTitle(i18n.T("review.section.suggestion"))
+		suggestedAnswer = sug.Answer
 
 		if suggestedAnswer == "" {
-			ui.Warning("Não foi possível gerar uma sugestão de resposta.")
+			ui.Warning(i18n.T("review.no_suggestion"))
+			s.Skip(pc, sentiment, SkipNoSuggestion)
 			return nil
 		}
 
-		answer = strings.TrimSpace(suggestedAnswer)
+		answer = suggestedAnswer
 		ui.PrintSuggestedAnswer(answer)
 
-		if sentiment.Sentimento == "positivo" && sentiment.Nota >= 4 && opts.AutoAnswerMode {
-			input = "S"
-			ui.Success("Resposta sugerida será publicada automaticamente.")
+		if s.ShouldAutoPublish(sentiment) && opts.AutoAnswerMode {
+			action = ui.ActionPublish
+			publishMode = PublishAuto
+			ui.Success(i18n.T("review.auto_publish"))
 
-			debuglog.Log("[countdown] início — path=publish")
-			completed := ui.Countdown(3*time.Minute, stdinCh, "Publicando em")
-			debuglog.Log("[countdown] fim — completed=%v path=publish", completed)
-			if !completed {
-				input = "E"
+			if !s.countdown(ctx, comment, opts, "publish", i18n.T("review.countdown.publish")) {
+				action = ui.ActionEdit
 			}
 		}
 
 		// Se não tem um opção do que fazer
-		if input == "" {
+		if action == ui.ActionNone {
 			// E está no modo auto resposta, mostra o countdown. Se o usuário não fizar nada, pula, senão deixa Editar
 			if opts.AutoAnswerMode {
-				debuglog.Log("[countdown] início — path=skip-with-suggestion")
-				completed := ui.Countdown(3*time.Minute, stdinCh, "Pulando comentário em")
-				debuglog.Log("[countdown] fim — completed=%v path=skip-with-suggestion", completed)
-				if !completed {
-					input = "E"
+				if !s.countdown(ctx, comment, opts, "skip-with-suggestion", i18n.T("review.countdown.skip")) {
+					action = ui.ActionEdit
 				}
 			}
 			// Se não está no modo autoresposta, mostra o menu de ações
 			if !opts.AutoAnswerMode {
 				ui.PrintActionMenu()
-				input, _ = reader.ReadString('\n')
-				input = strings.TrimSpace(strings.ToUpper(input))
+				line, ok := s.readDecision(ctx, "menu")
+				action = ui.ParseAction(line)
+				if !ok {
+					action = ui.ActionQuit
+				}
 			}
 		}
 	}
 
 	// Se ainda não tem uma mensagem sugerida
-	if suggestedAnswer == "" && input == "" {
+	if suggestedAnswer == "" && action == ui.ActionNone {
 		// E está no modo auto-resposta, mostra o countdown. Se o usuário não fizer nada, pula, senão deixa Editar
 		if opts.AutoAnswerMode {
-			debuglog.Log("[countdown] início — path=threshold reason=%q", thresholdReason(sentiment))
-			completed := ui.Countdown(3*time.Minute, stdinCh, thresholdReason(sentiment))
-			debuglog.Log("[countdown] fim — completed=%v path=threshold", completed)
-			if !completed {
-				input = "E"
+			if !s.countdown(ctx, comment, opts, "threshold", s.thresholdReason(sentiment)) {
+				action = ui.ActionEdit
 			}
 		}
 		// Se nao está no modo auto resposta, só mostra que não tem sugestão e manda editar
 		if !opts.AutoAnswerMode {
-			ui.Warning("Optei por não gerar uma resposta automática.")
-			input = "E"
+			ui.Warning(i18n.T("review.not_suggested"))
+			action = ui.ActionEdit
 		}
 	}
 
-	debuglog.Log("[comment] input final=%q antes do switch", input)
-	switch input {
-	case "S":
-		return s.publishAndSave(comment, &sentiment, answer, false)
-	case "E":
+	logger.Debug("decisão do revisor", append(commentAttrs(comment), "action", action)...)
+	switch action {
+	case ui.ActionPublish:
+		return s.publishAndSave(ctx, comment, sentiment, answer, publishMode)
+	case ui.ActionEdit:
 		ui.PrintEditPrompt()
-		var editedAnswer string
-		if opts.AutoAnswerMode {
-			editedAnswer = strings.TrimSpace(<-stdinCh)
-		} else {
-			line, _ := reader.ReadString('\n')
-			editedAnswer = strings.TrimSpace(line)
+		line, ok := s.readDecision(ctx, "edit")
+		if !ok {
+			return ErrUserQuit
 		}
+		editedAnswer := strings.TrimSpace(line)
 		if editedAnswer == "" {
-			ui.Warning("Resposta vazia — comentário ignorado.")
+			ui.Warning(i18n.T("review.empty_answer"))
+			s.Skip(pc, sentiment, SkipEmptyAnswer)
 			return nil
 		}
-		return s.publishAndSave(comment, &sentiment, editedAnswer, true)
-	case "Q":
-		os.Exit(0)
+		return s.publishAndSave(ctx, comment, sentiment, editedAnswer, PublishEdited)
+	case ui.ActionQuit:
+		return ErrUserQuit
 	default:
-		ui.Warning("Resposta não publicada.")
+		ui.Warning(i18n.T("review.not_published"))
+		// Sem ação, o countdown de pular chegou ao fim
+		reason := SkipByReviewer
+		if action == ui.ActionNone {
+			reason = SkipByCountdown
+		}
+		s.Skip(pc, sentiment, reason)
 	}
 
 	return nil
 }
 
-func isNetworkError(err error) bool {
-	var netErr net.Error
-	return errors.As(err, &netErr)
+// isAnsweredByMe reports whether the channel already replied to the thread.
+func isAnsweredByMe(thread *youtube.CommentThread, channelID string) bool {
+	if thread.Replies == nil {
+		return false
+	}
+	for _, reply := range thread.Replies.Comments {
+		if reply.Snippet.AuthorChannelId != nil && reply.Snippet.AuthorChannelId.Value == channelID {
+			return true
+		}
+	}
+	return false
 }
 
-func thresholdReason(s models.SentimentAnalysis) string {
-	if s.Sentimento != "positivo" {
-		return fmt.Sprintf("Sentimento %s —", s.Sentimento)
-	}
-	return fmt.Sprintf("Nota %d (mínimo 4) —", s.Nota)
+// readLine waits for the next line typed by the reviewer. ok is false when the
+// input source is exhausted.
+func (s *CommentService) readLine() (line string, ok bool) {
+	line, ok = <-s.Input.Lines()
+	logger.Debug("linha lida", "line", line, "ok", ok)
+	return line, ok
 }
 
-func (s *CommentService) publishAndSave(comment *youtube.Comment, sentiment *models.SentimentAnalysis, answer string, userAnswered bool) error {
-	err := yt.PublishComment(s.App.YTService, comment.Id, answer)
-	if err != nil {
-		return fmt.Errorf("falha ao publicar resposta: %w", err)
-	}
+// readDecision is readLine traced as the time the reviewer took to decide.
+func (s *CommentService) readDecision(ctx context.Context, prompt string) (string, bool) {
+	_, span := tracing.Start(ctx, tracing.SpanUserDecision, trace.WithAttributes(attribute.String("prompt", prompt)))
+	defer span.End()
+	return s.readLine()
+}
 
-	if err := database.SaveComment(comment, sentiment.Sentimento, sentiment.Nota, sentiment.Tema, answer, userAnswered); err != nil {
-		log.Printf("Erro ao salvar no banco: %v", err)
-		ui.Warning("Resposta publicada, mas houve erro ao salvar no histórico local!")
-	} else {
-		ui.Success("Resposta publicada e salva com sucesso!")
+// countdown runs the auto-answer countdown and reports whether it expired
+// without the reviewer pressing Enter. path identifies the branch in the log
+// and in the trace. Unattended passes have nobody to press Enter, so the
+// countdown expires at once.
+func (s *CommentService) countdown(ctx context.Context, comment *youtube.Comment, opts AnswerOptions, path string, msg string) bool {
+	if opts.Unattended {
+		logger.Debug("countdown dispensado (sem revisor)", append(commentAttrs(comment), "path", path)...)
+		return true
 	}
-	return nil
+
+	_, span := tracing.Start(ctx, tracing.SpanUserDecision, trace.WithAttributes(attribute.String("prompt", "countdown"), attribute.String("path", path)))
+	defer span.End()
+
+	logger.Debug("countdown iniciado", append(commentAttrs(comment), "path", path)...)
+	completed := ui.Countdown(s.App.Config.Review.Countdown, s.Clock, s.Input.Lines(), msg)
+	logger.Debug("countdown encerrado", append(commentAttrs(comment), "path", path, "completed", completed)...)
+	span.SetAttributes(attribute.Bool("completed", completed))
+	return completed
 }
 
-func (s *CommentService) loadMembersFromCSV(filename string) (map[string]bool, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		if os.IsNotExist(err) {
-			ui.Warning(fmt.Sprintf("Arquivo de membros '%s' não encontrado.", filename))
-			return make(map[string]bool), nil
-		}
-		return nil, err
+// commentAttrs returns the log attributes that identify a comment.
+func commentAttrs(comment *youtube.Comment) []any {
+	attrs := []any{logging.KeyCommentID, comment.Id, logging.KeyVideoID, comment.Snippet.VideoId}
+	if comment.Snippet.AuthorChannelId != nil {
+		attrs = append(attrs, logging.KeyAuthorChannelID, comment.Snippet.AuthorChannelId.Value)
 	}
-	defer file.Close()
+	return attrs
+}
 
-	if fileInfo, err := file.Stat(); err == nil {
-		if time.Since(fileInfo.ModTime()) > 10*24*time.Hour {
-			ui.Warning("Arquivo de membros desatualizado (mais de 10 dias).")
-		}
+func (s *CommentService) thresholdReason(sentiment models.SentimentAnalysis) string {
+	if sentiment.Sentimento != "positivo" {
+		return i18n.T("review.countdown.sentiment", ui.SentimentLabel(sentiment.Sentimento))
 	}
+	return i18n.T("review.countdown.score", sentiment.Nota, s.App.Config.Review.PublishMinScore)
+}
 
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
-	if err != nil {
-		return nil, err
+func (s *CommentService) publishAndSave(ctx context.Context, comment *youtube.Comment, sentiment models.SentimentAnalysis, answer string, mode PublishMode) error {
+	err := s.Publish(ctx, comment, sentiment, answer, mode)
+	if errors.Is(err, ErrNotSaved) {
+		logger.Error("resposta publicada mas não salva", append(commentAttrs(comment), logging.KeyError, err)...)
+		ui.Warning(i18n.T("review.published_not_saved"))
+		return nil
 	}
-
-	members := make(map[string]bool)
-	if len(records) > 1 {
-		for _, record := range records[1:] {
-			if len(record) > 0 {
-				channelId := record[1]
-				members[channelId] = true
-			}
-		}
+	if err != nil {
+		return err
 	}
-	return members, nil
+	ui.Success(i18n.T("review.published"))
+	return nil
 }
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"answer-comments/internal/input"
+	yt "answer-comments/internal/youtube"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+func TestIsAnsweredByMe(t *testing.T) {
+	reply := func(channelID string) *youtube.Comment {
+		c := &youtube.Comment{Snippet: &youtube.CommentSnippet{}}
+		if channelID != "" {
+			c.Snippet.AuthorChannelId = &youtube.CommentSnippetAuthorChannelId{Value: channelID}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		replies *youtube.CommentThreadReplies
+		want    bool
+	}{
+		{"no replies", nil, false},
+		{"empty replies", &youtube.CommentThreadReplies{}, false},
+		{"only other authors", &youtube.CommentThreadReplies{Comments: []*youtube.Comment{reply("UCoutro"), reply("UCmais")}}, false},
+		{"author without channel", &youtube.CommentThreadReplies{Comments: []*youtube.Comment{reply("")}}, false},
+		{"channel replied", &youtube.CommentThreadReplies{Comments: []*youtube.Comment{reply(testChannelID)}}, true},
+		{"channel replied after others", &youtube.CommentThreadReplies{Comments: []*youtube.Comment{reply("UCoutro"), reply(""), reply(testChannelID)}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thread := &youtube.CommentThread{Replies: tt.replies}
+			if got := isAnsweredByMe(thread, testChannelID); got != tt.want {
+				t.Errorf("isAnsweredByMe = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListUnansweredPages(t *testing.T) {
+	channel := yt.NewFakeChannel(testChannelID)
+	seedThreads(channel, 30, "Ótimo vídeo")
+	// c03 and c27 were answered in YouTube Studio; c05 only by a viewer
+	for _, id := range []string{"c03", "c27"} {
+		if err := channel.AddReply(id, "Canal", testChannelID, "Obrigado!", testNow); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := channel.AddReply("c05", "Outro", "UCoutro", "Também achei", testNow); err != nil {
+		t.Fatal(err)
+	}
+	s, _ := newTestService(t, channel, input.NewScript())
+
+	tests := []struct {
+		pageToken string
+		wantCount int
+		wantNext  string
+		skipped   []string
+	}{
+		{"", 24, "25", []string{"c03"}},
+		{"25", 4, "", []string{"c27"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("page %q", tt.pageToken), func(t *testing.T) {
+			comments, next, err := s.ListUnanswered(context.Background(), tt.pageToken)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(comments) != tt.wantCount || next != tt.wantNext {
+				t.Errorf("got %d comments, next %q; want %d, %q", len(comments), next, tt.wantCount, tt.wantNext)
+			}
+			for _, c := range comments {
+				for _, id := range tt.skipped {
+					if c.Id == id {
+						t.Errorf("%s já foi respondido pelo canal", id)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestProcessCommentsPages(t *testing.T) {
+	channel := yt.NewFakeChannel(testChannelID)
+	seedThreads(channel, 30, "Ótimo vídeo, obrigado!")
+	if err := channel.AddReply("c27", "Canal", testChannelID, "Obrigado!", testNow.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	s, _ := newTestService(t, channel, input.NewScript())
+
+	if err := s.ProcessComments(context.Background(), AnswerOptions{Unattended: true}); err != nil {
+		t.Fatalf("ProcessComments: %v", err)
+	}
+
+	for i := range 30 {
+		id := fmt.Sprintf("c%02d", i)
+		want := testAnswer
+		if id == "c27" {
+			want = "Obrigado!"
+		}
+		if got := channelReplies(channel, id); len(got) != 1 || got[0] != want {
+			t.Errorf("respostas do canal em %s = %q, want [%q]", id, got, want)
+		}
+	}
+	// Replies are dated by the injected clock
+	if got := channel.Replies("c00")[0].Snippet.PublishedAt; got != testNow.Format(time.RFC3339) {
+		t.Errorf("PublishedAt = %s, want %s", got, testNow.Format(time.RFC3339))
+	}
+}
+
+func TestHandleUnansweredCommentActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		lines   []string
+		want    []string // channel replies after the comment
+		wantErr error
+	}{
+		{"publish", "Ótimo vídeo", []string{"S"}, []string{testAnswer}, nil},
+		{"edit", "Ótimo vídeo", []string{"E", "Minha resposta"}, []string{"Minha resposta"}, nil},
+		{"edit empty", "Ótimo vídeo", []string{"E", "  "}, nil, nil},
+		{"input closed at edit", "Ótimo vídeo", []string{"E"}, nil, ErrUserQuit},
+		{"skip", "Ótimo vídeo", []string{"N"}, nil, nil},
+		{"unknown key skips", "Ótimo vídeo", []string{"x"}, nil, nil},
+		{"quit", "Ótimo vídeo", []string{"Q"}, nil, ErrUserQuit},
+		{"input closed at menu", "Ótimo vídeo", nil, nil, ErrUserQuit},
+		{"negative goes to edit", "Que vídeo ruim", []string{"Calma, vou melhorar"}, []string{"Calma, vou melhorar"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel := yt.NewFakeChannel(testChannelID)
+			seedThreads(channel, 1, tt.text)
+			script := input.NewScript(tt.lines...)
+			script.Close()
+			s, _ := newTestService(t, channel, script)
+
+			comment, err := s.GetComment(context.Background(), "c00")
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.handleUnansweredComment(context.Background(), comment, AnswerOptions{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got := channelReplies(channel, "c00"); !slices.Equal(got, tt.want) {
+				t.Errorf("respostas = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleUnansweredCommentCountdown(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		// enter presses Enter during the countdown and then types the edited
+		// answer; otherwise the countdown runs out
+		enter bool
+		want  []string
+	}{
+		{"auto publish", "Ótimo vídeo", false, []string{testAnswer}},
+		{"enter edits before publishing", "Ótimo vídeo", true, []string{"Resposta revisada"}},
+		{"skip below threshold", "Tenho uma dúvida", false, nil},
+		{"enter edits instead of skipping", "Tenho uma dúvida", true, []string{"Resposta revisada"}},
+		{"negative without suggestion", "Que vídeo ruim", false, nil},
+		{"enter answers a negative comment", "Que vídeo ruim", true, []string{"Resposta revisada"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel := yt.NewFakeChannel(testChannelID)
+			seedThreads(channel, 1, tt.text)
+			script := input.NewScript()
+			s, clk := newTestService(t, channel, script)
+
+			comment, err := s.GetComment(context.Background(), "c00")
+			if err != nil {
+				t.Fatal(err)
+			}
+			done := make(chan error, 1)
+			go func() {
+				done <- s.handleUnansweredComment(context.Background(), comment, AnswerOptions{AutoAnswerMode: true})
+			}()
+
+			clk.BlockUntil(1)
+			if tt.enter {
+				script.Send("")
+				script.Send("Resposta revisada")
+			} else {
+				clk.Advance(s.App.Config.Review.Countdown)
+			}
+			if err := <-done; err != nil {
+				t.Fatal(err)
+			}
+			if got := channelReplies(channel, "c00"); !slices.Equal(got, tt.want) {
+				t.Errorf("respostas = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/genai"
+)
+
+// Errors that end a review session. They are returned wrapped, so callers
+// match them with errors.Is and still get the underlying cause in the message.
+var (
+	// ErrUserQuit is returned when the reviewer chooses to quit (Q) or the
+	// input ends.
+	ErrUserQuit = errors.New("sessão encerrada pelo usuário")
+	// ErrNetwork is returned when the network is down; continuing would skip
+	// every remaining comment silently.
+	ErrNetwork = errors.New("erro de rede")
+	// ErrQuotaExceeded is returned when the YouTube Data API quota is over.
+	ErrQuotaExceeded = errors.New("cota da API do YouTube esgotada")
+	// ErrLLMUnavailable is returned when Gemini cannot be reached or refuses
+	// requests (rate limit, server errors, timeouts).
+	ErrLLMUnavailable = errors.New("LLM indisponível")
+)
+
+// classifyYouTubeError wraps err with ErrQuotaExceeded or ErrNetwork when it
+// matches one of them, and returns it unchanged otherwise.
+func classifyYouTubeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if isQuotaError(err) {
+		return fmt.Errorf("%w: %w", ErrQuotaExceeded, err)
+	}
+	if isNetworkError(err) {
+		return fmt.Errorf("%w: %w", ErrNetwork, err)
+	}
+	return err
+}
+
+// classifyLLMError wraps err with ErrLLMUnavailable when Gemini is down or
+// throttling, and with ErrNetwork when the whole network is. Other errors
+// (e.g. an unparsable answer) are returned unchanged.
+func classifyLLMError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if isNetworkError(err) {
+		return fmt.Errorf("%w: %w", ErrNetwork, err)
+	}
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) && (apiErr.Code == http.StatusTooManyRequests || apiErr.Code >= 500) {
+		return fmt.Errorf("%w: %w", ErrLLMUnavailable, err)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrLLMUnavailable, err)
+	}
+	return err
+}
+
+// isFatal reports whether err must end the session instead of moving on to
+// the next comment.
+func isFatal(err error) bool {
+	return errors.Is(err, ErrUserQuit) || errors.Is(err, ErrNetwork) ||
+		errors.Is(err, ErrQuotaExceeded) || errors.Is(err, ErrLLMUnavailable)
+}
+
+func isQuotaError(err error) bool {
+	var gErr *googleapi.Error
+	if !errors.As(err, &gErr) {
+		return false
+	}
+	if gErr.Code == http.StatusTooManyRequests {
+		return true
+	}
+	if gErr.Code != http.StatusForbidden {
+		return false
+	}
+	for _, item := range gErr.Errors {
+		switch item.Reason {
+		case "quotaExceeded", "dailyLimitExceeded", "rateLimitExceeded":
+			return true
+		}
+	}
+	return false
+}
+
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
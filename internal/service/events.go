@@ -0,0 +1,130 @@
+package service
+
+import (
+	"errors"
+
+	"answer-comments/internal/metrics"
+	"answer-comments/internal/models"
+	"answer-comments/internal/webhook"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// PublishMode tells how a published answer was produced.
+type PublishMode string
+
+const (
+	// PublishAuto is a suggestion published by the auto-answer countdown.
+	PublishAuto PublishMode = "auto"
+	// PublishAccepted is a suggestion the reviewer published unchanged.
+	PublishAccepted PublishMode = "accepted"
+	// PublishEdited is an answer written or edited by the reviewer.
+	PublishEdited PublishMode = "edited"
+)
+
+// Reasons reported by reply.skipped events.
+const (
+	SkipByReviewer   = "reviewer"
+	SkipByCountdown  = "countdown"
+	SkipEmptyAnswer  = "empty_answer"
+	SkipNoSuggestion = "no_suggestion"
+)
+
+// Skip records that the comment was left unanswered (metrics and webhook).
+func (s *CommentService) Skip(pc PendingComment, sentiment models.SentimentAnalysis, reason string) {
+	p := s.payload(pc.Comment)
+	p.VideoTitle = pc.VideoTitle
+	setAnalysis(&p, sentiment)
+	p.Reason = reason
+	metrics.CommentSkipped(reason)
+	s.App.Webhooks.Emit(webhook.ReplySkipped, p)
+
+	s.mu.Lock()
+	if s.skipped == nil {
+		s.skipped = make(map[string]bool)
+	}
+	s.skipped[pc.Comment.Id] = true
+	s.mu.Unlock()
+}
+
+// notSkipped drops the comments skipped earlier in this process, so repeated
+// unattended passes do not analyze them again.
+func (s *CommentService) notSkipped(comments []*youtube.Comment) []*youtube.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := comments[:0]
+	for _, c := range comments {
+		if !s.skipped[c.Id] {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// received emits comment.received the first time a comment is prepared in
+// this session, so the frontends that prepare a comment more than once do not
+// repeat the event.
+func (s *CommentService) received(pc PendingComment) {
+	s.mu.Lock()
+	seen := s.seen[pc.Comment.Id]
+	if s.seen == nil {
+		s.seen = make(map[string]bool)
+	}
+	s.seen[pc.Comment.Id] = true
+	s.mu.Unlock()
+	if seen {
+		return
+	}
+
+	p := s.payload(pc.Comment)
+	p.VideoTitle = pc.VideoTitle
+	s.App.Webhooks.Emit(webhook.CommentReceived, p)
+}
+
+// pipelineError emits pipeline.error for a failed step. comment may be nil.
+func (s *CommentService) pipelineError(comment *youtube.Comment, err error) {
+	var p webhook.Payload
+	if comment != nil {
+		p = s.payload(comment)
+	}
+	p.Error = err.Error()
+	p.ErrorKind = errorKind(err)
+	s.App.Webhooks.Emit(webhook.PipelineError, p)
+}
+
+func (s *CommentService) payload(comment *youtube.Comment) webhook.Payload {
+	p := webhook.Payload{
+		CommentID:  comment.Id,
+		VideoID:    comment.Snippet.VideoId,
+		AuthorName: comment.Snippet.AuthorDisplayName,
+		Text:       comment.Snippet.TextOriginal,
+		IsMember:   s.IsMember(comment),
+	}
+	if comment.Snippet.AuthorChannelId != nil {
+		p.AuthorChannelID = comment.Snippet.AuthorChannelId.Value
+	}
+	return p
+}
+
+func setAnalysis(p *webhook.Payload, sentiment models.SentimentAnalysis) {
+	p.Sentiment = sentiment.Sentimento
+	p.Score = sentiment.Nota
+	p.Theme = sentiment.Tema
+	p.Language = sentiment.Idioma
+}
+
+// errorKind names the class of err for pipeline.error events.
+func errorKind(err error) string {
+	switch {
+	case errors.Is(err, ErrQuotaExceeded):
+		return "quota"
+	case errors.Is(err, ErrLLMUnavailable):
+		return "llm"
+	case errors.Is(err, ErrNetwork):
+		return "network"
+	case errors.Is(err, ErrNotSaved):
+		return "database"
+	default:
+		return "other"
+	}
+}
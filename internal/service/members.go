@@ -0,0 +1,193 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"answer-comments/internal/database"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/logging"
+	"answer-comments/internal/members"
+	"answer-comments/internal/models"
+	"answer-comments/internal/ui"
+	yt "answer-comments/internal/youtube"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// Sources of the members list.
+const (
+	memberSourceCSV = "csv" // export from YouTube Studio
+	memberSourceAPI = "api" // Members API
+)
+
+// LoadMembers loads the channel members used to flag comments from members.
+// Depending on Config.Members.Source, the list is synced from the Members API
+// (at most once per Config.Members.Refresh), imported from the CSV export when
+// the file changed, or the API is tried first with the CSV as fallback. The
+// members then come from the members table, so the last good list is still
+// used when both sources fail. Later calls are no-ops until
+// Config.Members.Refresh has passed since the last load, so long-running
+// sessions call it at the start of every pass or request.
+func (s *CommentService) LoadMembers(ctx context.Context) {
+	s.membersLoad.Lock()
+	defer s.membersLoad.Unlock()
+
+	now := s.Clock.Now()
+	s.mu.Lock()
+	fresh := s.members != nil && now.Sub(s.membersLoaded) < s.App.Config.Members.Refresh
+	s.mu.Unlock()
+	if fresh {
+		return
+	}
+
+	var err error
+	switch s.App.Config.Members.Source {
+	case memberSourceCSV:
+		err = s.importMembersCSV(s.App.Config.Members.CSVFile)
+	case memberSourceAPI:
+		err = s.syncMembersAPI(ctx)
+	default:
+		err = s.syncMembersAPI(ctx)
+		if errors.Is(err, yt.ErrMembersUnavailable) {
+			logger.Debug("usando a lista de membros em CSV", logging.KeyError, err)
+			err = s.importMembersCSV(s.App.Config.Members.CSVFile)
+		}
+	}
+	if err != nil {
+		logger.Warn("não foi possível atualizar a lista de membros", logging.KeyError, err)
+	}
+
+	members := make(map[string]models.Member)
+	list, err := database.ListMembers()
+	if err != nil {
+		logger.Warn("não foi possível carregar a lista de membros", logging.KeyError, err)
+	}
+	for _, m := range list {
+		members[m.ChannelID] = m
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Keep the previous list when the table cannot be read
+	if err == nil || s.members == nil {
+		s.members = members
+	}
+	s.membersLoaded = now
+}
+
+// MemberCount returns how many members were loaded by LoadMembers.
+func (s *CommentService) MemberCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.members)
+}
+
+// IsMember reports whether the comment author is a channel member, according
+// to the list loaded by LoadMembers.
+func (s *CommentService) IsMember(comment *youtube.Comment) bool {
+	_, ok := s.Member(comment)
+	return ok
+}
+
+// Member returns the membership of the comment author. ok is false for
+// non-members.
+func (s *CommentService) Member(comment *youtube.Comment) (m models.Member, ok bool) {
+	if comment.Snippet.AuthorChannelId == nil {
+		return m, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok = s.members[comment.Snippet.AuthorChannelId.Value]
+	return m, ok
+}
+
+// syncMembersAPI replaces the stored members with the ones from the Members
+// API, unless the last attempt is more recent than Config.Members.Refresh.
+// Failed attempts are recorded too, so a channel without access is not asked
+// again before the interval; it then returns ErrMembersUnavailable at once.
+func (s *CommentService) syncMembersAPI(ctx context.Context) error {
+	if s.App.YT == nil {
+		return fmt.Errorf("%w: cliente do YouTube não inicializado", yt.ErrMembersUnavailable)
+	}
+	last, ok, err := database.LastMemberImport(memberSourceAPI)
+	if err != nil {
+		return err
+	}
+	if ok && s.Clock.Now().Sub(last.ImportedAt) < s.App.Config.Members.Refresh {
+		if last.Error != "" {
+			return fmt.Errorf("%w: %s", yt.ErrMembersUnavailable, last.Error)
+		}
+		return nil
+	}
+
+	list, err := yt.FetchMembers(ctx, s.App.YT)
+	if err != nil {
+		if errors.Is(err, yt.ErrMembersUnavailable) {
+			if serr := database.SaveFailedMemberImport(database.MemberImport{Source: memberSourceAPI, Error: err.Error()}); serr != nil {
+				logger.Warn("não foi possível registrar a tentativa de sincronização", logging.KeyError, serr)
+			}
+		}
+		return err
+	}
+	if err := database.ReplaceMembers(list, database.MemberImport{Source: memberSourceAPI, Members: len(list)}); err != nil {
+		return err
+	}
+	logger.Info("lista de membros sincronizada pela API", "members", len(list))
+	return nil
+}
+
+// importMembersCSV imports the members export into the database, unless the
+// same file is the last one imported.
+func (s *CommentService) importMembersCSV(filename string) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			ui.Warning(i18n.T("members.csv_not_found", filename))
+			return nil
+		}
+		return err
+	}
+	if s.Clock.Now().Sub(info.ModTime()) > 10*24*time.Hour {
+		ui.Warning(i18n.T("members.csv_stale"))
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	if last, ok, err := database.LastMemberImport(""); err != nil {
+		return err
+	} else if ok && last.Source == memberSourceCSV && last.FileHash == hash {
+		return nil
+	}
+
+	// Tenures in months count back from the export date, not from today.
+	list, skipped, err := members.ParseCSV(bytes.NewReader(data), info.ModTime())
+	if err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+	err = database.ReplaceMembers(list, database.MemberImport{
+		Source:   memberSourceCSV,
+		File:     filename,
+		FileHash: hash,
+		Members:  len(list),
+		Skipped:  skipped,
+	})
+	if err != nil {
+		return err
+	}
+	logger.Info("lista de membros importada", "file", filename, "members", len(list), "skipped", skipped)
+	if skipped > 0 {
+		ui.Warning(i18n.N("members.csv_skipped", skipped))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"answer-comments/internal/input"
+	yt "answer-comments/internal/youtube"
+)
+
+// Channel IDs of the members in the test exports.
+const (
+	member1 = "UCmembro1aaaaaaaaaaaaaaa"
+	member2 = "UCmembro2aaaaaaaaaaaaaaa"
+)
+
+// writeMembersCSV writes a members export listing the given channels.
+func writeMembersCSV(t *testing.T, s *CommentService, channels ...string) {
+	t.Helper()
+	data := "Member,Link to profile\n"
+	for _, id := range channels {
+		data += id + ",https://www.youtube.com/channel/" + id + "\n"
+	}
+	if err := os.WriteFile(s.App.Config.Members.CSVFile, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadMembersRefresh(t *testing.T) {
+	ctx := context.Background()
+	s, clk := newTestService(t, yt.NewFakeChannel(testChannelID), input.NewScript())
+
+	writeMembersCSV(t, s, member1)
+	s.LoadMembers(ctx)
+	if n := s.MemberCount(); n != 1 {
+		t.Fatalf("%d membros, want 1", n)
+	}
+
+	// Within the refresh interval the loaded list is kept
+	writeMembersCSV(t, s, member1, member2)
+	clk.Advance(time.Hour)
+	s.LoadMembers(ctx)
+	if n := s.MemberCount(); n != 1 {
+		t.Errorf("%d membros antes do intervalo, want 1", n)
+	}
+
+	clk.Advance(s.App.Config.Members.Refresh)
+	s.LoadMembers(ctx)
+	if n := s.MemberCount(); n != 2 {
+		t.Errorf("%d membros após o intervalo, want 2", n)
+	}
+}
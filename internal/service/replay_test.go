@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/clock"
+	"answer-comments/internal/database"
+	"answer-comments/internal/input"
+)
+
+// TestProcessCommentsReplay runs an unattended session against the recorded
+// cassette in testdata: two pages of threads, one already answered, a
+// positive comment that gets published and a negative one that is skipped.
+// Every YouTube and Gemini call must come from the fixture.
+func TestProcessCommentsReplay(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HTTP_CASSETTE", "testdata/process_comments.json")
+	t.Setenv("HTTP_CASSETTE_MODE", "replay")
+	t.Setenv("GEMINI_API_KEY", "")
+	t.Setenv("DATABASE_FILE", filepath.Join(dir, "comments.db"))
+	t.Setenv("MEMBERS_SOURCE", "csv")
+	t.Setenv("MEMBERS_CSV_FILE", filepath.Join(dir, "members.csv"))
+	// The prompts are part of the recorded request bodies
+	t.Setenv("PROMPT_ANALYSIS", "Classifique o comentário e responda só com JSON (sentimento, nota, tema, idioma): {{COMMENT}}")
+	t.Setenv("PROMPT_POSITIVE_ANSWER", "Agradeça o comentário {{COMMENT}} sobre o vídeo {{TITLE}}. {{LANGUAGE}}")
+	t.Setenv("PROMPT_NEGATIVE_ANSWER", "Responda com calma ao comentário {{COMMENT}} sobre o vídeo {{TITLE}}. {{LANGUAGE}}")
+
+	a, err := app.NewApp(context.Background(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(a.Close)
+
+	s := &CommentService{App: a, Input: input.NewScript(), Clock: clock.Real{}}
+	if err := s.ProcessComments(context.Background(), AnswerOptions{Unattended: true}); err != nil {
+		t.Fatalf("ProcessComments: %v", err)
+	}
+	if n := a.Cassette().Unused(); n != 0 {
+		t.Errorf("%d interações gravadas não foram usadas", n)
+	}
+
+	saved, err := database.GetLastComments("Ana", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 1 || saved[0].Response != "Que bom que ajudou, Ana! Valeu pelo carinho." {
+		t.Errorf("histórico de Ana = %+v, want a resposta publicada", saved)
+	}
+	for _, author := range []string{"Bruno", "Carlos"} {
+		if saved, err := database.GetLastComments(author, 10); err != nil || len(saved) != 0 {
+			t.Errorf("histórico de %s = %+v, %v; want vazio", author, saved, err)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"answer-comments/internal/database"
+	"answer-comments/internal/llm"
+	"answer-comments/internal/logging"
+	"answer-comments/internal/metrics"
+	"answer-comments/internal/models"
+	"answer-comments/internal/tracing"
+	"answer-comments/internal/webhook"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/api/youtube/v3"
+)
+
+// ErrNotSaved is returned by Publish when the reply went out but could not be
+// stored in the local history.
+var ErrNotSaved = errors.New("resposta publicada, mas não salva no histórico local")
+
+// ErrAlreadyAnswered is returned by GetUnansweredComment when the channel
+// already replied to the comment.
+var ErrAlreadyAnswered = errors.New("o canal já respondeu a este comentário")
+
+// PendingComment is an unanswered top-level comment with the context shown to
+// the reviewer.
+type PendingComment struct {
+	Comment          *youtube.Comment
+	PublishedAt      time.Time
+	VideoTitle       string
+	VideoDescription string
+	IsMember         bool
+	Member           models.Member // level and tenure, when IsMember
+}
+
+// Suggestion is a generated answer and a summary of the context used for it.
+type Suggestion struct {
+	Answer        string
+	TranscriptLen int // 0 = not fetched, -1 = error, >0 = char count
+	AuthorHistory int // past interactions with the author
+	PastAnswers   int // similar past answers (RAG)
+}
+
+// ListUnanswered fetches one page of threads and returns the top-level
+// comments the channel has not replied to yet, plus the next page token
+// ("" on the last page).
+func (s *CommentService) ListUnanswered(ctx context.Context, pageToken string) ([]*youtube.Comment, string, error) {
+	response, err := s.App.YT.ListThreads(ctx, s.App.ChannelID, pageToken, 25)
+	if err != nil {
+		err = fmt.Errorf("erro ao buscar os comentários: %w", classifyYouTubeError(err))
+		s.pipelineError(nil, err)
+		return nil, "", err
+	}
+
+	var comments []*youtube.Comment
+	for _, item := range response.Items {
+		if !isAnsweredByMe(item, s.App.ChannelID) {
+			comments = append(comments, item.Snippet.TopLevelComment)
+		}
+	}
+	metrics.CommentsFetched(len(comments))
+	return comments, response.NextPageToken, nil
+}
+
+// GetComment fetches a top-level comment by ID.
+func (s *CommentService) GetComment(ctx context.Context, commentID string) (*youtube.Comment, error) {
+	thread, err := s.App.YT.GetThread(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar o comentário: %w", classifyYouTubeError(err))
+	}
+	return thread.Snippet.TopLevelComment, nil
+}
+
+// GetUnansweredComment is GetComment for a comment about to be answered: it
+// fails with ErrAlreadyAnswered when the thread already has a reply from the
+// channel, so a retried request does not publish a second one.
+func (s *CommentService) GetUnansweredComment(ctx context.Context, commentID string) (*youtube.Comment, error) {
+	thread, err := s.App.YT.GetThread(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar o comentário: %w", classifyYouTubeError(err))
+	}
+	if isAnsweredByMe(thread, s.App.ChannelID) {
+		return nil, fmt.Errorf("comentário %s: %w", commentID, ErrAlreadyAnswered)
+	}
+	return thread.Snippet.TopLevelComment, nil
+}
+
+// Prepare gathers the video and membership context of a comment, reloading
+// the members list first when it is older than Config.Members.Refresh. Video
+// lookup failures are not fatal: placeholders are used instead. The first
+// call for a comment emits comment.received.
+func (s *CommentService) Prepare(ctx context.Context, comment *youtube.Comment) PendingComment {
+	s.LoadMembers(ctx)
+	publishedAt, _ := time.Parse(time.RFC3339, comment.Snippet.PublishedAt)
+	pc := PendingComment{
+		Comment:          comment,
+		PublishedAt:      publishedAt,
+		VideoTitle:       "[Não foi possível obter o título]",
+		VideoDescription: "[Não foi possível obter a descrição]",
+	}
+	pc.Member, pc.IsMember = s.Member(comment)
+
+	_, span := tracing.Start(ctx, tracing.SpanVideoFetch)
+	video, err := s.App.YT.GetVideo(ctx, comment.Snippet.VideoId)
+	tracing.End(span, err)
+	if err == nil {
+		pc.VideoTitle = video.Snippet.Title
+		pc.VideoDescription = video.Snippet.Description
+	}
+	s.received(pc)
+	return pc
+}
+
+// Analyze classifies the comment's sentiment, score and theme.
+func (s *CommentService) Analyze(ctx context.Context, pc PendingComment) (models.SentimentAnalysis, error) {
+	ctx, span := tracing.Start(llm.WithCommentID(ctx, pc.Comment.Id), tracing.SpanAnalyze)
+	sentiment, err := s.App.LLM.AnalyzeComment(ctx, pc.Comment.Snippet.TextOriginal)
+	span.SetAttributes(attribute.String("sentiment", sentiment.Sentimento), attribute.Int("score", sentiment.Nota), attribute.String("theme", sentiment.Tema), attribute.String("language", sentiment.Idioma))
+	tracing.End(span, err)
+	if err != nil {
+		err = fmt.Errorf("erro na análise de sentimento: %w", classifyLLMError(err))
+		s.pipelineError(pc.Comment, err)
+		return models.SentimentAnalysis{}, err
+	}
+
+	metrics.CommentAnalyzed(sentiment.Sentimento)
+	p := s.payload(pc.Comment)
+	p.VideoTitle = pc.VideoTitle
+	setAnalysis(&p, sentiment)
+	s.App.Webhooks.Emit(webhook.CommentAnalyzed, p)
+	return sentiment, nil
+}
+
+// ShouldSuggest reports whether the analysis is confident enough to ask the
+// LLM for an answer.
+func (s *CommentService) ShouldSuggest(sentiment models.SentimentAnalysis) bool {
+	return sentiment.Sentimento != "negativo" && sentiment.Nota >= s.App.Config.Review.SuggestMinScore
+}
+
+// ShouldAutoPublish reports whether a suggested answer may be published
+// without an explicit confirmation in auto-answer mode.
+func (s *CommentService) ShouldAutoPublish(sentiment models.SentimentAnalysis) bool {
+	return sentiment.Sentimento == "positivo" && sentiment.Nota >= s.App.Config.Review.PublishMinScore
+}
+
+// Suggest generates an answer in the comment's language using the author's
+// history, similar past answers and, when transcription is true, the video
+// transcript (in the comment's language when the video has that caption).
+func (s *CommentService) Suggest(ctx context.Context, pc PendingComment, sentiment models.SentimentAnalysis, transcription bool) (Suggestion, error) {
+	var sug Suggestion
+
+	_, span := tracing.Start(ctx, tracing.SpanRAGQuery)
+	pastAnswers, err := database.GetPreviousAnswersByContext(sentiment.Tema, sentiment.Sentimento, 5)
+	span.SetAttributes(attribute.Int("results", len(pastAnswers)))
+	tracing.End(span, err)
+	if err != nil {
+		logger.Error("erro ao buscar respostas anteriores (RAG)", append(commentAttrs(pc.Comment), logging.KeyError, err)...)
+	}
+
+	_, span = tracing.Start(ctx, tracing.SpanAuthorHistory)
+	authorHistory, err := database.GetLastComments(pc.Comment.Snippet.AuthorDisplayName, 10)
+	span.SetAttributes(attribute.Int("results", len(authorHistory)))
+	tracing.End(span, err)
+	if err != nil {
+		logger.Error("erro ao buscar histórico do autor", append(commentAttrs(pc.Comment), logging.KeyError, err)...)
+	}
+
+	var videoTranscript string
+	if transcription && sentiment.Tema != "Saudação/Agradecimento" {
+		tctx, span := tracing.Start(ctx, tracing.SpanTranscript)
+		videoTranscript, err = s.App.YT.GetTranscript(tctx, pc.Comment.Snippet.VideoId, sentiment.Idioma)
+		span.SetAttributes(attribute.Int("chars", len(videoTranscript)))
+		tracing.End(span, err)
+		if err != nil {
+			logger.Warn("não foi possível obter a transcrição", append(commentAttrs(pc.Comment), logging.KeyError, err)...)
+			sug.TranscriptLen = -1
+		} else {
+			sug.TranscriptLen = len(videoTranscript)
+		}
+	}
+	sug.AuthorHistory = len(authorHistory)
+	sug.PastAnswers = len(pastAnswers)
+
+	gctx, span := tracing.Start(llm.WithCommentID(ctx, pc.Comment.Id), tracing.SpanGenerate)
+	var member *models.Member
+	if pc.IsMember {
+		member = &pc.Member
+	}
+	answer, err := s.App.LLM.SuggestAnswer(gctx, llm.AnswerRequest{
+		Comment:          pc.Comment.Snippet.TextOriginal,
+		Negative:         sentiment.Sentimento == "negativo",
+		Language:         sentiment.Idioma,
+		VideoTitle:       pc.VideoTitle,
+		VideoDescription: pc.VideoDescription,
+		VideoTranscript:  videoTranscript,
+		AuthorHistory:    authorHistory,
+		Member:           member,
+		PastAnswers:      pastAnswers,
+	})
+	tracing.End(span, err)
+	if err != nil {
+		err = fmt.Errorf("erro ao sugerir resposta: %w", classifyLLMError(err))
+		s.pipelineError(pc.Comment, err)
+		return sug, err
+	}
+	sug.Answer = strings.TrimSpace(answer)
+	return sug, nil
+}
+
+// Publish posts the answer as a reply and stores it in the local history.
+// mode tells how the text was produced; edited answers are the ones used as
+// examples for future suggestions. When the reply is published but not saved,
+// the returned error wraps ErrNotSaved.
+func (s *CommentService) Publish(ctx context.Context, comment *youtube.Comment, sentiment models.SentimentAnalysis, answer string, mode PublishMode) error {
+	pctx, span := tracing.Start(ctx, tracing.SpanPublish, trace.WithAttributes(attribute.String("mode", string(mode))))
+	err := s.App.YT.PublishReply(pctx, comment.Id, answer)
+	tracing.End(span, err)
+	if err != nil {
+		err = fmt.Errorf("falha ao publicar resposta: %w", classifyYouTubeError(err))
+		s.pipelineError(comment, err)
+		return err
+	}
+
+	commentedAt, _ := time.Parse(time.RFC3339, comment.Snippet.PublishedAt)
+	metrics.ReplyPublished(string(mode), commentedAt, s.Clock.Now())
+	p := s.payload(comment)
+	setAnalysis(&p, sentiment)
+	p.Answer = answer
+	p.Mode = string(mode)
+	s.App.Webhooks.Emit(webhook.ReplyPublished, p)
+
+	if err := database.SaveComment(comment, sentiment.Sentimento, sentiment.Nota, sentiment.Tema, sentiment.Idioma, answer, mode == PublishEdited, s.IsMember(comment)); err != nil {
+		err = fmt.Errorf("%w: %w", ErrNotSaved, err)
+		s.pipelineError(comment, err)
+		return err
+	}
+	return nil
+}
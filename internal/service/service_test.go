@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/clock"
+	"answer-comments/internal/database"
+	"answer-comments/internal/input"
+	"answer-comments/internal/llm"
+	yt "answer-comments/internal/youtube"
+
+	"google.golang.org/genai"
+)
+
+const testChannelID = "UCcanal"
+
+// testNow is the time of the fake clock shared by the service and the fake
+// channel.
+var testNow = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+// testAnswer is the answer the fake Gemini generates for every comment.
+const testAnswer = "Valeu pelo comentário!"
+
+// fakeGemini answers the Gemini API: comments containing "ruim" are
+// negative, "dúvida" neutral with score 3, everything else positive with
+// score 5.
+type fakeGemini struct{}
+
+func (fakeGemini) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	prompt := string(body)
+
+	text := testAnswer
+	if strings.Contains(req.URL.Path, "analysis") {
+		switch {
+		case strings.Contains(prompt, "ruim"):
+			text = `{"sentimento": "negativo", "nota": 2, "tema": "Crítica"}`
+		case strings.Contains(prompt, "dúvida"):
+			text = `{"sentimento": "neutro", "nota": 3, "tema": "Dúvida técnica"}`
+		default:
+			text = `{"sentimento": "positivo", "nota": 5, "tema": "Elogio"}`
+		}
+	}
+	resp, err := json.Marshal(map[string]any{
+		"candidates": []any{map[string]any{
+			"content": map[string]any{"role": "model", "parts": []any{map[string]any{"text": text}}},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(string(resp))),
+		Request:    req,
+	}, nil
+}
+
+// newTestService returns a CommentService over channel, a fake Gemini and a
+// fresh database, reading the reviewer's input from script and using a fake
+// clock set to testNow.
+func newTestService(t *testing.T, channel *yt.FakeChannel, script *input.Script) (*CommentService, *clock.Fake) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := database.InitDB(filepath.Join(dir, "comments.db")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(database.CloseDB)
+
+	cfg := &app.Config{
+		Members: app.MembersConfig{Source: "csv", CSVFile: filepath.Join(dir, "members.csv"), Refresh: 24 * time.Hour},
+		LLM: llm.Config{
+			AnalysisModel:   "analysis",
+			GenerationModel: "generation",
+			Prompts: llm.Prompts{
+				Analysis:       "Analise: {{COMMENT}}",
+				PositiveAnswer: "Agradeça: {{COMMENT}}",
+				NegativeAnswer: "Responda com calma: {{COMMENT}}",
+			},
+		},
+		Review: app.ReviewConfig{SuggestMinScore: 3, PublishMinScore: 4, Countdown: 3 * time.Minute},
+	}
+	gemini, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+		APIKey:     "test",
+		Backend:    genai.BackendGeminiAPI,
+		HTTPClient: &http.Client{Transport: fakeGemini{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clk := clock.NewFake(testNow)
+	channel.Clock = clk
+	a := &app.App{Config: cfg, YT: channel, LLM: llm.NewClient(gemini, cfg.LLM), ChannelID: testChannelID}
+	return &CommentService{App: a, Input: script, Clock: clk}, clk
+}
+
+// seedThreads adds n unanswered comments on video v1, the first one the
+// newest, with IDs c00, c01...
+func seedThreads(channel *yt.FakeChannel, n int, text string) {
+	channel.AddVideo("v1", "Vídeo de teste", "Descrição", "")
+	for i := range n {
+		channel.AddThread("v1", fmt.Sprintf("c%02d", i), fmt.Sprintf("Autor %d", i), fmt.Sprintf("UCautor%d", i), text, testNow.Add(-time.Duration(i+1)*time.Hour))
+	}
+}
+
+// channelReplies returns the replies of the channel owner to a thread.
+func channelReplies(channel *yt.FakeChannel, threadID string) []string {
+	var texts []string
+	for _, r := range channel.Replies(threadID) {
+		if r.Snippet.AuthorChannelId.Value == testChannelID {
+			texts = append(texts, r.Snippet.TextOriginal)
+		}
+	}
+	return texts
+}
@@ -0,0 +1,284 @@
+// Package stats computes engagement reports over the local reply history.
+package stats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"answer-comments/internal/database"
+	"answer-comments/internal/i18n"
+)
+
+// Count is one bucket of a distribution.
+type Count struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// VideoStats is the breakdown of one video.
+type VideoStats struct {
+	VideoID  string  `json:"video_id"`
+	Replies  int     `json:"replies"`
+	Edited   int     `json:"edited"`
+	EditRate float64 `json:"edit_rate"`
+	Negative int     `json:"negative"`
+	// MedianTimeToReplySeconds is 0 when unknown.
+	MedianTimeToReplySeconds float64 `json:"median_time_to_reply_seconds"`
+}
+
+// Report aggregates the reply history.
+type Report struct {
+	Since   time.Time `json:"since,omitzero"`
+	Replies int       `json:"replies"`
+	// Edited counts replies written or edited by the reviewer instead of
+	// published as suggested.
+	Edited                   int          `json:"edited"`
+	EditRate                 float64      `json:"edit_rate"`
+	MedianTimeToReplySeconds float64      `json:"median_time_to_reply_seconds"`
+	PerDay                   []Count      `json:"per_day"`
+	PerWeek                  []Count      `json:"per_week"`
+	Themes                   []Count      `json:"themes"`
+	Sentiments               []Count      `json:"sentiments"`
+	TopCommenters            []Count      `json:"top_commenters"`
+	Members                  []Count      `json:"members"`
+	Videos                   []VideoStats `json:"videos"`
+}
+
+// Compute builds the report from the stored comments. top limits the
+// commenters list.
+func Compute(comments []database.DBComment, since time.Time, top int) Report {
+	rep := Report{Since: since, Replies: len(comments)}
+
+	perDay := make(map[string]int)
+	perWeek := make(map[string]int)
+	themes := make(map[string]int)
+	sentiments := make(map[string]int)
+	authors := make(map[string]int)
+	members := make(map[string]int)
+	videos := make(map[string]*VideoStats)
+	videoDelays := make(map[string][]time.Duration)
+	var delays []time.Duration
+
+	for _, c := range comments {
+		if c.UserAnswered {
+			rep.Edited++
+		}
+		if !c.RespondedAt.IsZero() {
+			local := c.RespondedAt.Local()
+			perDay[local.Format("2006-01-02")]++
+			year, week := local.ISOWeek()
+			perWeek[fmt.Sprintf("%d-W%02d", year, week)]++
+		}
+		themes[orEmpty(c.Theme)]++
+		sentiments[orEmpty(c.Sentiment)]++
+		authors[c.Author]++
+		switch {
+		case !c.IsMember.Valid:
+			members["desconhecido"]++
+		case c.IsMember.Bool:
+			members["membro"]++
+		default:
+			members["não membro"]++
+		}
+
+		v := videos[c.VideoID]
+		if v == nil {
+			v = &VideoStats{VideoID: c.VideoID}
+			videos[c.VideoID] = v
+		}
+		v.Replies++
+		if c.UserAnswered {
+			v.Edited++
+		}
+		if c.Sentiment == "negativo" {
+			v.Negative++
+		}
+
+		if !c.CreatedAt.IsZero() && !c.RespondedAt.IsZero() && c.RespondedAt.After(c.CreatedAt) {
+			d := c.RespondedAt.Sub(c.CreatedAt)
+			delays = append(delays, d)
+			videoDelays[c.VideoID] = append(videoDelays[c.VideoID], d)
+		}
+	}
+
+	if rep.Replies > 0 {
+		rep.EditRate = float64(rep.Edited) / float64(rep.Replies)
+	}
+	rep.MedianTimeToReplySeconds = median(delays).Seconds()
+	rep.PerDay = byKey(perDay)
+	rep.PerWeek = byKey(perWeek)
+	rep.Themes = byCount(themes, 0)
+	rep.Sentiments = byCount(sentiments, 0)
+	rep.TopCommenters = byCount(authors, top)
+	rep.Members = byCount(members, 0)
+
+	for id, v := range videos {
+		v.EditRate = float64(v.Edited) / float64(v.Replies)
+		v.MedianTimeToReplySeconds = median(videoDelays[id]).Seconds()
+		rep.Videos = append(rep.Videos, *v)
+	}
+	sort.Slice(rep.Videos, func(i, j int) bool {
+		if rep.Videos[i].Replies != rep.Videos[j].Replies {
+			return rep.Videos[i].Replies > rep.Videos[j].Replies
+		}
+		return rep.Videos[i].VideoID < rep.Videos[j].VideoID
+	})
+	return rep
+}
+
+// PrintReport writes the report as terminal tables.
+func PrintReport(w io.Writer, rep Report) {
+	fmt.Fprintln(w, i18n.T("stats.report.replies", rep.Replies))
+	fmt.Fprintln(w, i18n.T("stats.report.edited", rep.Edited, rep.EditRate*100))
+	fmt.Fprintln(w, i18n.T("stats.report.median", formatDuration(rep.MedianTimeToReplySeconds)))
+
+	printCounts(w, i18n.T("stats.report.per_day"), i18n.T("stats.column.day"), rep.PerDay)
+	printCounts(w, i18n.T("stats.report.per_week"), i18n.T("stats.column.week"), rep.PerWeek)
+	printCounts(w, i18n.T("stats.report.themes"), i18n.T("stats.column.theme"), rep.Themes)
+	printCounts(w, i18n.T("stats.report.sentiments"), i18n.T("stats.column.sentiment"), rep.Sentiments)
+	printCounts(w, i18n.T("stats.report.top"), i18n.T("stats.column.author"), rep.TopCommenters)
+	printCounts(w, i18n.T("stats.report.members"), i18n.T("stats.column.group"), rep.Members)
+
+	fmt.Fprintf(w, "\n%s\n", i18n.T("stats.report.videos"))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "%s\t\n", i18n.T("stats.columns.videos"))
+	for _, v := range rep.Videos {
+		fmt.Fprintf(tw, "%s\t%d\t%.0f%%\t%d\t%s\t\n", v.VideoID, v.Replies, v.EditRate*100, v.Negative, formatDuration(v.MedianTimeToReplySeconds))
+	}
+	tw.Flush()
+}
+
+// WriteJSON writes the report as indented JSON.
+func WriteJSON(w io.Writer, rep Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(rep)
+}
+
+// WriteCSV writes the report in long format: one "report,key,value" row per
+// figure, so every section fits a single sheet.
+func WriteCSV(w io.Writer, rep Report) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"report", "key", "value"})
+	cw.Write([]string{"summary", "replies", strconv.Itoa(rep.Replies)})
+	cw.Write([]string{"summary", "edited", strconv.Itoa(rep.Edited)})
+	cw.Write([]string{"summary", "edit_rate", formatFloat(rep.EditRate)})
+	cw.Write([]string{"summary", "median_time_to_reply_seconds", formatFloat(rep.MedianTimeToReplySeconds)})
+
+	sections := []struct {
+		name   string
+		counts []Count
+	}{
+		{"per_day", rep.PerDay},
+		{"per_week", rep.PerWeek},
+		{"theme", rep.Themes},
+		{"sentiment", rep.Sentiments},
+		{"top_commenter", rep.TopCommenters},
+		{"members", rep.Members},
+	}
+	for _, s := range sections {
+		for _, c := range s.counts {
+			cw.Write([]string{s.name, c.Key, strconv.Itoa(c.Count)})
+		}
+	}
+	for _, v := range rep.Videos {
+		cw.Write([]string{"video_replies", v.VideoID, strconv.Itoa(v.Replies)})
+		cw.Write([]string{"video_edit_rate", v.VideoID, formatFloat(v.EditRate)})
+		cw.Write([]string{"video_negative", v.VideoID, strconv.Itoa(v.Negative)})
+		cw.Write([]string{"video_median_time_to_reply_seconds", v.VideoID, formatFloat(v.MedianTimeToReplySeconds)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func printCounts(w io.Writer, title, column string, counts []Count) {
+	fmt.Fprintf(w, "\n%s\n", title)
+	if len(counts) == 0 {
+		fmt.Fprintf(w, "  %s\n", i18n.T("stats.no_data"))
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "%s\t%s\t\n", column, i18n.T("stats.column.count"))
+	for _, c := range counts {
+		fmt.Fprintf(tw, "%s\t%d\t\n", c.Key, c.Count)
+	}
+	tw.Flush()
+}
+
+// byKey returns the buckets sorted by key (chronological for dates).
+func byKey(m map[string]int) []Count {
+	counts := make([]Count, 0, len(m))
+	for k, v := range m {
+		counts = append(counts, Count{k, v})
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Key < counts[j].Key })
+	return counts
+}
+
+// byCount returns the buckets, largest first, keeping at most limit (0 = all).
+func byCount(m map[string]int, limit int) []Count {
+	counts := make([]Count, 0, len(m))
+	for k, v := range m {
+		counts = append(counts, Count{k, v})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Key < counts[j].Key
+	})
+	if limit > 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts
+}
+
+func median(ds []time.Duration) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func orEmpty(s string) string {
+	if s == "" {
+		return "(vazio)"
+	}
+	return s
+}
+
+// formatDuration renders seconds as "3d 4h", "5h 12m" or "8m".
+func formatDuration(seconds float64) string {
+	if seconds <= 0 {
+		return "—"
+	}
+	d := time.Duration(seconds * float64(time.Second))
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	mins := int(d.Minutes()) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, mins)
+	default:
+		return fmt.Sprintf("%dm", mins)
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,136 @@
+// Package tracing configures OpenTelemetry tracing. Each processed comment
+// is one trace, with a span per pipeline step, exported via OTLP or to a
+// local file.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const serviceName = "answer-comments"
+
+// Span names of a comment's lifecycle.
+const (
+	SpanComment       = "comment"
+	SpanVideoFetch    = "video.fetch"
+	SpanAnalyze       = "comment.analyze"
+	SpanRAGQuery      = "rag.query"
+	SpanAuthorHistory = "rag.author_history"
+	SpanTranscript    = "transcript.download"
+	SpanGenerate      = "answer.generate"
+	SpanUserDecision  = "user.decision"
+	SpanPublish       = "reply.publish"
+)
+
+// Options selects the trace exporter. It is part of the application
+// configuration (the tracing section).
+type Options struct {
+	// Exporter is "" or "none" (tracing disabled, spans are no-ops), "otlp"
+	// (OTLP over HTTP) or "file" (one JSON span per line).
+	Exporter string `yaml:"exporter" env:"TRACE_EXPORTER"`
+	// File receives the spans of the file exporter (default traces.jsonl).
+	File string `yaml:"file" env:"TRACE_FILE"`
+	// OTLPEndpoint is the base URL of the OTLP collector, as in the standard
+	// variable; "" leaves it to the other OTEL_EXPORTER_OTLP_* variables
+	// (default localhost:4318).
+	OTLPEndpoint string `yaml:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+}
+
+// Setup installs the global tracer provider selected by opts. The returned
+// function flushes and stops the exporter; it must be called before the
+// process exits.
+func Setup(ctx context.Context, opts Options) (shutdown func(context.Context) error, err error) {
+	noop := func(context.Context) error { return nil }
+
+	var exporter sdktrace.SpanExporter
+	switch opts.Exporter {
+	case "", "none":
+		return noop, nil
+	case "otlp":
+		var httpOpts []otlptracehttp.Option
+		if opts.OTLPEndpoint != "" {
+			// Like the standard variable, the endpoint is a base URL and
+			// traces go to its /v1/traces path
+			httpOpts = append(httpOpts, otlptracehttp.WithEndpointURL(strings.TrimRight(opts.OTLPEndpoint, "/")+"/v1/traces"))
+		}
+		exporter, err = otlptracehttp.New(ctx, httpOpts...)
+		if err != nil {
+			return noop, fmt.Errorf("tracing: erro ao criar exportador OTLP: %w", err)
+		}
+	case "file":
+		path := opts.File
+		if path == "" {
+			path = "traces.jsonl"
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return noop, fmt.Errorf("tracing: não foi possível abrir %s: %w", path, err)
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return noop, fmt.Errorf("tracing: erro ao criar exportador de arquivo: %w", err)
+		}
+		exporter = closingExporter{exporter, f}
+	default:
+		return noop, fmt.Errorf("tracing: TRACE_EXPORTER desconhecido %q (use otlp, file ou none)", opts.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// Start starts a span with the application tracer.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(serviceName).Start(ctx, name, opts...)
+}
+
+// StartComment starts the root span of a comment's trace, detached from any
+// span in ctx so every comment gets its own trace.
+func StartComment(ctx context.Context, commentID, videoID string) (context.Context, trace.Span) {
+	return Start(ctx, SpanComment, trace.WithNewRoot(), trace.WithAttributes(
+		attribute.String("comment_id", commentID),
+		attribute.String("video_id", videoID),
+	))
+}
+
+// End records err (if any) on span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// closingExporter closes the trace file after the exporter shuts down.
+type closingExporter struct {
+	sdktrace.SpanExporter
+	f *os.File
+}
+
+func (e closingExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if cerr := e.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
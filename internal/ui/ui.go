@@ -5,9 +5,15 @@ import (
 	"strings"
 	"time"
 
+	"answer-comments/internal/clock"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/logging"
+
 	"golang.org/x/term"
 )
 
+var logger = logging.For(logging.UI)
+
 // ── ANSI Codes ────────────────────────────────────────────────────────────────
 
 const (
@@ -106,18 +112,30 @@ func PrintDivider() {
 
 // SentimentBadge returns a colored inline badge for a sentiment value.
 func SentimentBadge(sentimento string) string {
+	label := fmt.Sprintf(" ● %-8s ", strings.ToUpper(SentimentLabel(sentimento)))
 	switch strings.ToLower(sentimento) {
 	case "positivo":
-		return BgGreen + FgBlack + Bold + " ● POSITIVO " + Reset
+		return BgGreen + FgBlack + Bold + label + Reset
 	case "negativo":
-		return BgRed + FgWhite + Bold + " ● NEGATIVO " + Reset
+		return BgRed + FgWhite + Bold + label + Reset
 	case "neutro":
-		return BgYellow + FgBlack + Bold + " ● NEUTRO   " + Reset
+		return BgYellow + FgBlack + Bold + label + Reset
 	default:
 		return BgBlue + FgWhite + Bold + " ● " + strings.ToUpper(sentimento) + " " + Reset
 	}
 }
 
+// SentimentLabel returns the sentiment value of the analysis ("positivo",
+// "negativo", "neutro") in the current locale.
+func SentimentLabel(sentimento string) string {
+	switch s := strings.ToLower(sentimento); s {
+	case "positivo", "negativo", "neutro":
+		return i18n.T("sentiment." + s)
+	default:
+		return sentimento
+	}
+}
+
 // NotaBadge returns a colored inline badge for the analysis score (1-5).
 func NotaBadge(nota int) string {
 	stars := strings.Repeat("★", nota) + strings.Repeat("☆", 5-nota)
@@ -135,9 +153,18 @@ func ThemeBadge(tema string) string {
 	return FgBrightMagenta + "[🏷  " + tema + "]" + Reset
 }
 
+// LanguageBadge returns a styled inline tag with the comment's language code,
+// or "" when the language is unknown.
+func LanguageBadge(code string) string {
+	if code == "" {
+		return ""
+	}
+	return FgBrightCyan + "[🌐 " + strings.ToUpper(code) + "]" + Reset
+}
+
 // MemberBadge returns a styled member badge string.
 func MemberBadge() string {
-	return BgBrightMagenta + FgWhite + Bold + " ⭐ MEMBRO " + Reset + " "
+	return BgBrightMagenta + FgWhite + Bold + " ⭐ " + i18n.T("ui.member_badge") + " " + Reset + " "
 }
 
 // ── Field Printing ────────────────────────────────────────────────────────────
@@ -154,7 +181,7 @@ func PrintField(label, value string) {
 // PrintComment prints the comment text with a distinct style inside a subtle block.
 func PrintComment(text string) {
 	fmt.Println()
-	fmt.Println("  " + FgBrightYellow + Bold + "💬 Comentário:" + Reset)
+	fmt.Println("  " + FgBrightYellow + Bold + "💬 " + i18n.T("ui.comment") + Reset)
 	lines := wrapText(text, termWidth()-6)
 	for _, line := range lines {
 		fmt.Println("  " + FgYellow + "> " + Reset + line)
@@ -193,22 +220,51 @@ func Muted(msg string) {
 
 // ── Action Prompt ─────────────────────────────────────────────────────────────
 
+// Action is the reviewer's choice in the action menu.
+type Action string
+
+const (
+	// ActionNone means no choice was made (e.g. a countdown ran out).
+	ActionNone    Action = ""
+	ActionPublish Action = "publish"
+	ActionEdit    Action = "edit"
+	ActionSkip    Action = "skip"
+	ActionQuit    Action = "quit"
+)
+
+// Key returns the key bound to the action (see i18n.SetKeys).
+func Key(a Action) string {
+	return i18n.Key(string(a))
+}
+
+// ParseAction maps a line typed at the action menu to an action, using the
+// key bindings of Key. Any other input skips the comment.
+func ParseAction(line string) Action {
+	input := strings.TrimSpace(strings.ToUpper(line))
+	for _, a := range []Action{ActionPublish, ActionEdit, ActionSkip, ActionQuit} {
+		if input == Key(a) {
+			return a
+		}
+	}
+	return ActionSkip
+}
+
 // PrintActionMenu prints a styled action menu and returns the prompt string.
 func PrintActionMenu() {
 	fmt.Println()
 	PrintDivider()
-	fmt.Printf("  %sAção:%s  ", Bold+FgBrightWhite, Reset)
-	fmt.Printf("%s[S]%s Publicar   ", BgGreen+FgBlack+Bold, Reset)
-	fmt.Printf("%s[E]%s Editar   ", BgBrightBlue+FgWhite+Bold, Reset)
-	fmt.Printf("%s[N]%s Pular   ", BgYellow+FgBlack+Bold, Reset)
-	fmt.Printf("%s[Q]%s Sair", BgRed+FgWhite+Bold, Reset)
+	fmt.Printf("  %s%s%s  ", Bold+FgBrightWhite, i18n.T("ui.action"), Reset)
+	fmt.Printf("%s[%s]%s %s   ", BgGreen+FgBlack+Bold, Key(ActionPublish), Reset, i18n.T("ui.action.publish"))
+	fmt.Printf("%s[%s]%s %s   ", BgBrightBlue+FgWhite+Bold, Key(ActionEdit), Reset, i18n.T("ui.action.edit"))
+	fmt.Printf("%s[%s]%s %s   ", BgYellow+FgBlack+Bold, Key(ActionSkip), Reset, i18n.T("ui.action.skip"))
+	fmt.Printf("%s[%s]%s %s", BgRed+FgWhite+Bold, Key(ActionQuit), Reset, i18n.T("ui.action.quit"))
 	fmt.Printf(" %s→ %s", FgBrightCyan+Bold, Reset)
 }
 
 // PrintEditPrompt prints a styled prompt for manual answer input.
 func PrintEditPrompt() {
 	fmt.Println()
-	fmt.Printf("  %s✏️  Digite sua resposta:%s\n", Bold+FgBrightBlue, Reset)
+	fmt.Printf("  %s✏️  %s%s\n", Bold+FgBrightBlue, i18n.T("ui.edit_prompt"), Reset)
 	fmt.Printf("  %s→ %s", FgBrightCyan+Bold, Reset)
 }
 
@@ -216,7 +272,7 @@ func PrintEditPrompt() {
 
 func PrintSearchingBanner() {
 	fmt.Println()
-	fmt.Println(Dim + FgCyan + "  🔍 Buscando novos comentários não respondidos..." + Reset)
+	fmt.Println(Dim + FgCyan + "  🔍 " + i18n.T("ui.searching") + Reset)
 	fmt.Println()
 }
 
@@ -281,7 +337,7 @@ func PrintContextBar(transcriptLen, authorCount, pastCount int) {
 		kb := float64(transcriptLen) / 1000
 		transcriptPart = FgBrightCyan + Bold + "📄" + Reset + " " + FgBrightCyan + fmt.Sprintf("%.1fk chars", kb) + Reset
 	case transcriptLen == -1:
-		transcriptPart = Dim + FgWhite + "📄 indisponível" + Reset
+		transcriptPart = Dim + FgWhite + "📄 " + i18n.T("ui.context.unavailable") + Reset
 	default:
 		transcriptPart = Dim + FgWhite + "📄 —" + Reset
 	}
@@ -289,12 +345,7 @@ func PrintContextBar(transcriptLen, authorCount, pastCount int) {
 	// ── Author history ────────────────────────────────────────────────────────
 	var authorPart string
 	if authorCount > 0 {
-		authorPart = FgBrightBlue + Bold + "👥" + Reset + " " + FgBrightBlue + fmt.Sprintf("%d interaç", authorCount)
-		if authorCount == 1 {
-			authorPart += "ão" + Reset
-		} else {
-			authorPart += "ões" + Reset
-		}
+		authorPart = FgBrightBlue + Bold + "👥" + Reset + " " + FgBrightBlue + i18n.N("ui.context.interactions", authorCount) + Reset
 	} else {
 		authorPart = Dim + FgWhite + "👥 —" + Reset
 	}
@@ -302,12 +353,7 @@ func PrintContextBar(transcriptLen, authorCount, pastCount int) {
 	// ── Similar answers ───────────────────────────────────────────────────────
 	var pastPart string
 	if pastCount > 0 {
-		pastPart = FgBrightMagenta + Bold + "🗂" + Reset + "  " + FgBrightMagenta + fmt.Sprintf("%d similar", pastCount)
-		if pastCount != 1 {
-			pastPart += "es" + Reset
-		} else {
-			pastPart += Reset
-		}
+		pastPart = FgBrightMagenta + Bold + "🗂" + Reset + "  " + FgBrightMagenta + i18n.N("ui.context.similar", pastCount) + Reset
 	} else {
 		pastPart = Dim + FgWhite + "🗂  —" + Reset
 	}
@@ -319,17 +365,18 @@ func PrintContextBar(transcriptLen, authorCount, pastCount int) {
 
 // Countdown exibe um contador regressivo inline, atualizando a cada segundo.
 // msg é o texto exibido antes do tempo (ex: "Publicando em" ou "Nota 3 — revisando").
-// stdinCh é o channel compartilhado de leitura de stdin (ver ProcessComments).
+// lines é o channel compartilhado de entrada do usuário (ver input.Source) e clk
+// a fonte de tempo (clock.Real em produção, clock.Fake nos testes).
 // Retorna true se o tempo esgotou normalmente, false se o usuário cancelou (Enter).
-func Countdown(d time.Duration, stdinCh <-chan string, msg string) bool {
+func Countdown(d time.Duration, clk clock.Clock, lines <-chan string, msg string) bool {
 	remaining := d
-	ticker := time.NewTicker(time.Second)
+	ticker := clk.NewTicker(time.Second)
 	defer ticker.Stop()
 
 	for {
 		mins := int(remaining.Minutes())
 		secs := int(remaining.Seconds()) % 60
-		label := fmt.Sprintf(" ⏱  %s %d:%02d... [Enter para editar] ", msg, mins, secs)
+		label := " ⏱  " + i18n.T("ui.countdown", msg, mins, secs) + " "
 
 		// === BLINK_ALERT: pisca fundo vermelho no último minuto; remova este bloco se não quiser ===
 		if remaining <= time.Minute {
@@ -345,10 +392,17 @@ func Countdown(d time.Duration, stdinCh <-chan string, msg string) bool {
 		fmt.Printf("\r  %s   ", label)
 
 		select {
-		case <-stdinCh:
+		case _, ok := <-lines:
+			if !ok {
+				// Entrada encerrada: ninguém pode cancelar, deixa o tempo esgotar
+				logger.Debug("entrada encerrada durante o countdown")
+				lines = nil
+				continue
+			}
 			fmt.Println()
+			logger.Debug("countdown interrompido pelo revisor", "remaining", remaining)
 			return false
-		case <-ticker.C:
+		case <-ticker.C():
 			remaining -= time.Second
 			if remaining <= 0 {
 				fmt.Println()
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"answer-comments/internal/clock"
+	"answer-comments/internal/i18n"
+)
+
+func TestCountdown(t *testing.T) {
+	tests := []struct {
+		name string
+		// run drives the countdown once its ticker is active
+		run  func(clk *clock.Fake, lines chan string)
+		want bool
+	}{
+		{"expires", func(clk *clock.Fake, lines chan string) {
+			clk.Advance(3 * time.Minute)
+		}, true},
+		{"enter cancels", func(clk *clock.Fake, lines chan string) {
+			clk.Advance(time.Minute)
+			lines <- ""
+		}, false},
+		{"enter in the last second", func(clk *clock.Fake, lines chan string) {
+			clk.Advance(3*time.Minute - time.Second)
+			lines <- ""
+		}, false},
+		{"closed input waits for the end", func(clk *clock.Fake, lines chan string) {
+			close(lines)
+			clk.Advance(3 * time.Minute)
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFake(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+			lines := make(chan string)
+			done := make(chan bool, 1)
+			go func() {
+				done <- Countdown(3*time.Minute, clk, lines, "publicando")
+			}()
+
+			clk.BlockUntil(1)
+			tt.run(clk, lines)
+			if got := <-done; got != tt.want {
+				t.Errorf("Countdown = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseActionKeys(t *testing.T) {
+	t.Cleanup(func() {
+		i18n.Set(i18n.PtBR)
+		i18n.SetKeys(i18n.PtBR)
+	})
+
+	tests := []struct {
+		name     string
+		messages i18n.Locale
+		keys     i18n.Locale
+		line     string
+		want     Action
+	}{
+		{"pt publish", i18n.PtBR, i18n.PtBR, "s", ActionPublish},
+		{"pt skip", i18n.PtBR, i18n.PtBR, "N", ActionSkip},
+		// English messages from LANG keep the Portuguese keys
+		{"LANG=en publish", i18n.En, i18n.PtBR, "S", ActionPublish},
+		{"LANG=en edit", i18n.En, i18n.PtBR, "e", ActionEdit},
+		{"--lang en publish", i18n.En, i18n.En, "P", ActionPublish},
+		{"--lang en skip", i18n.En, i18n.En, "S", ActionSkip},
+		{"quit", i18n.En, i18n.En, " q ", ActionQuit},
+		{"unknown skips", i18n.PtBR, i18n.PtBR, "x", ActionSkip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i18n.Set(tt.messages)
+			i18n.SetKeys(tt.keys)
+			if got := ParseAction(tt.line); got != tt.want {
+				t.Errorf("ParseAction(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
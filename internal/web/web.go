@@ -0,0 +1,420 @@
+// Package web serves a local HTML review queue on top of the comment service,
+// as an alternative to the terminal flow for long comments.
+package web
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/logging"
+	"answer-comments/internal/models"
+	"answer-comments/internal/service"
+	"answer-comments/internal/tracing"
+	"answer-comments/internal/ui"
+)
+
+var logger = logging.For(logging.Web)
+
+//go:embed templates/*.html
+var templates embed.FS
+
+// Review states of a queued comment.
+const (
+	statusPending      = "pending"
+	statusPublishing   = "publishing"   // a publish request is in flight
+	statusRegenerating = "regenerating" // a new suggestion is being generated
+	statusPublished    = "published"
+	statusSkipped      = "skipped"
+)
+
+// Server is the review UI. It keeps the queue in memory: comments are
+// fetched page by page from YouTube, analyzed once and kept until reviewed.
+type Server struct {
+	svc           *service.CommentService
+	transcription bool
+	tmpl          *template.Template
+
+	loadMu sync.Mutex // serializes page loads, which call YouTube and Gemini
+
+	mu       sync.Mutex
+	items    map[string]*item
+	order    []string
+	nextPage string
+	loaded   bool
+	flash    string
+}
+
+type item struct {
+	Pending    service.PendingComment
+	Analysis   models.SentimentAnalysis
+	Suggestion service.Suggestion
+	Status     string
+	Error      string
+}
+
+// NewServer creates the review UI. transcription enables the video
+// transcript as context for generation, like the -t flag of the terminal
+// flow.
+func NewServer(svc *service.CommentService, transcription bool) (*Server, error) {
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"sentimentClass": sentimentClass,
+		"sentiment":      ui.SentimentLabel,
+		"stars":          stars,
+		"date": func(t time.Time) string {
+			return t.In(time.FixedZone("BRT", -3*60*60)).Format(i18n.T("review.date_layout"))
+		},
+		"t":    i18n.T,
+		"n":    i18n.N,
+		"lang": i18n.Current,
+	}).ParseFS(templates, "templates/*.html")
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		svc:           svc,
+		transcription: transcription,
+		tmpl:          tmpl,
+		items:         make(map[string]*item),
+	}, nil
+}
+
+// Handler returns the HTTP handler of the review UI.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("POST /load", s.handleLoad)
+	mux.HandleFunc("POST /comments/{id}/publish", s.handlePublish)
+	mux.HandleFunc("POST /comments/{id}/skip", s.handleSkip)
+	mux.HandleFunc("POST /comments/{id}/regenerate", s.handleRegenerate)
+	// Every POST publishes, skips or spends LLM calls: a form on another
+	// site must not be able to submit them from the reviewer's browser
+	return localOnly(http.NewCrossOriginProtection().Handler(mux))
+}
+
+// ListenAndServe serves the UI on addr until ctx is cancelled. addr must be a
+// loopback address: the UI publishes on behalf of the channel and has no
+// authentication.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	if err := checkLoopback(addr); err != nil {
+		return err
+	}
+	srv := &http.Server{Addr: addr, Handler: s.Handler()}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+type indexData struct {
+	Items    []*item
+	Videos   []string
+	Themes   []string
+	Video    string
+	Theme    string
+	Pending  int
+	HasMore  bool
+	Loaded   bool
+	Flash    string
+	Members  int
+	Reviewed int
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	video := r.URL.Query().Get("video")
+	theme := r.URL.Query().Get("theme")
+
+	s.mu.Lock()
+	data := indexData{
+		Video:   video,
+		Theme:   theme,
+		HasMore: !s.loaded || s.nextPage != "",
+		Loaded:  s.loaded,
+		Flash:   s.flash,
+		Members: s.svc.MemberCount(),
+	}
+	s.flash = ""
+
+	videos := make(map[string]bool)
+	themes := make(map[string]bool)
+	for _, id := range s.order {
+		it := s.items[id]
+		switch it.Status {
+		case statusPublished, statusSkipped:
+			data.Reviewed++
+			continue
+		case statusPublishing, statusRegenerating:
+			continue
+		}
+		data.Pending++
+		videos[it.Pending.VideoTitle] = true
+		themes[it.Analysis.Tema] = true
+		if (video == "" || it.Pending.VideoTitle == video) && (theme == "" || it.Analysis.Tema == theme) {
+			copied := *it
+			data.Items = append(data.Items, &copied)
+		}
+	}
+	s.mu.Unlock()
+
+	data.Videos = sortedKeys(videos)
+	data.Themes = sortedKeys(themes)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.tmpl.ExecuteTemplate(w, "index.html", data); err != nil {
+		logger.Error("erro ao renderizar página", logging.KeyError, err)
+	}
+}
+
+// handleLoad fetches the next page of unanswered comments, analyzing each one
+// and generating a suggestion when the analysis is confident enough.
+func (s *Server) handleLoad(w http.ResponseWriter, r *http.Request) {
+	s.loadMu.Lock()
+	defer s.loadMu.Unlock()
+
+	ctx := r.Context()
+	s.mu.Lock()
+	pageToken, loaded := s.nextPage, s.loaded
+	s.mu.Unlock()
+
+	if loaded && pageToken == "" {
+		s.setFlash(i18n.T("review.no_more_comments"))
+		s.redirect(w, r)
+		return
+	}
+
+	comments, nextPage, err := s.svc.ListUnanswered(ctx, pageToken)
+	if err != nil {
+		s.setFlash(err.Error())
+		s.redirect(w, r)
+		return
+	}
+
+	added := 0
+	for _, comment := range comments {
+		s.mu.Lock()
+		_, known := s.items[comment.Id]
+		s.mu.Unlock()
+		if known {
+			continue
+		}
+
+		cctx, span := tracing.StartComment(ctx, comment.Id, comment.Snippet.VideoId)
+		it := &item{Pending: s.svc.Prepare(cctx, comment), Status: statusPending}
+		it.Analysis, err = s.svc.Analyze(cctx, it.Pending)
+		if err != nil {
+			it.Error = err.Error()
+		} else if s.svc.ShouldSuggest(it.Analysis) {
+			it.Suggestion, err = s.svc.Suggest(cctx, it.Pending, it.Analysis, s.transcription)
+			if err != nil {
+				it.Error = err.Error()
+			}
+		}
+		tracing.End(span, err)
+
+		s.mu.Lock()
+		s.items[comment.Id] = it
+		s.order = append(s.order, comment.Id)
+		s.mu.Unlock()
+		added++
+	}
+
+	s.mu.Lock()
+	s.nextPage = nextPage
+	s.loaded = true
+	s.mu.Unlock()
+
+	if added == 0 {
+		s.setFlash(i18n.T("review.no_more_in_batch"))
+	} else {
+		s.setFlash(i18n.N("web.added", added))
+	}
+	s.redirect(w, r)
+}
+
+func (s *Server) handlePublish(w http.ResponseWriter, r *http.Request) {
+	it, ok := s.claimItem(w, r, statusPublishing)
+	if !ok {
+		return
+	}
+	answer := strings.TrimSpace(r.FormValue("answer"))
+	if answer == "" {
+		s.setStatus(it, statusPending)
+		s.setFlash(i18n.T("web.empty_answer"))
+		s.redirect(w, r)
+		return
+	}
+
+	mode := service.PublishAccepted
+	if answer != it.Suggestion.Answer {
+		mode = service.PublishEdited
+	}
+	err := s.svc.Publish(r.Context(), it.Pending.Comment, it.Analysis, answer, mode)
+	switch {
+	case errors.Is(err, service.ErrNotSaved):
+		logger.Error("resposta publicada mas não salva", logging.KeyCommentID, it.Pending.Comment.Id, logging.KeyError, err)
+		s.setStatus(it, statusPublished)
+		s.setFlash(i18n.T("review.published_not_saved"))
+	case err != nil:
+		s.setStatus(it, statusPending)
+		s.setFlash(err.Error())
+	default:
+		s.setStatus(it, statusPublished)
+		s.setFlash(i18n.T("review.published"))
+	}
+	s.redirect(w, r)
+}
+
+func (s *Server) handleSkip(w http.ResponseWriter, r *http.Request) {
+	it, ok := s.claimItem(w, r, statusSkipped)
+	if !ok {
+		return
+	}
+	s.svc.Skip(it.Pending, it.Analysis, service.SkipByReviewer)
+	s.setFlash(i18n.T("review.not_published"))
+	s.redirect(w, r)
+}
+
+// handleRegenerate asks for a new suggestion, even when the analysis is below
+// the threshold used to suggest automatically.
+func (s *Server) handleRegenerate(w http.ResponseWriter, r *http.Request) {
+	it, ok := s.claimItem(w, r, statusRegenerating)
+	if !ok {
+		return
+	}
+	sug, err := s.svc.Suggest(r.Context(), it.Pending, it.Analysis, s.transcription)
+
+	s.mu.Lock()
+	if err != nil {
+		it.Error = err.Error()
+	} else {
+		it.Suggestion = sug
+		it.Error = ""
+	}
+	it.Status = statusPending
+	s.mu.Unlock()
+	s.redirect(w, r)
+}
+
+// claimItem moves the pending comment named in the path to status, so that
+// a second request for the same comment (a double click or another tab)
+// gets a 404 instead of publishing or skipping it again. The item must be
+// moved back to statusPending when the action does not complete.
+func (s *Server) claimItem(w http.ResponseWriter, r *http.Request, status string) (*item, bool) {
+	s.mu.Lock()
+	it, ok := s.items[r.PathValue("id")]
+	claimed := ok && it.Status == statusPending
+	if claimed {
+		it.Status = status
+	}
+	s.mu.Unlock()
+	if !claimed {
+		http.Error(w, i18n.T("web.not_found"), http.StatusNotFound)
+		return nil, false
+	}
+	return it, true
+}
+
+func (s *Server) setStatus(it *item, status string) {
+	s.mu.Lock()
+	it.Status = status
+	s.mu.Unlock()
+}
+
+func (s *Server) setFlash(msg string) {
+	s.mu.Lock()
+	s.flash = msg
+	s.mu.Unlock()
+}
+
+// redirect sends the browser back to the queue keeping the active filters.
+func (s *Server) redirect(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/?"+filterQuery(r.FormValue("video"), r.FormValue("theme")), http.StatusSeeOther)
+}
+
+// localOnly rejects requests whose Host is not a loopback name, protecting
+// the UI against DNS rebinding from pages open in the same browser.
+func localOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if !isLoopbackHost(host) {
+			http.Error(w, "acesso permitido apenas via localhost", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func checkLoopback(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("endereço inválido %q: %w", addr, err)
+	}
+	if !isLoopbackHost(host) {
+		return fmt.Errorf("o servidor só pode escutar em localhost (recebido %q)", host)
+	}
+	return nil
+}
+
+func isLoopbackHost(host string) bool {
+	host = strings.Trim(host, "[]")
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func filterQuery(video, theme string) string {
+	q := url.Values{}
+	if video != "" {
+		q.Set("video", video)
+	}
+	if theme != "" {
+		q.Set("theme", theme)
+	}
+	return q.Encode()
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sentimentClass(sentimento string) string {
+	switch strings.ToLower(sentimento) {
+	case "positivo", "negativo", "neutro":
+		return strings.ToLower(sentimento)
+	default:
+		return "outro"
+	}
+}
+
+func stars(nota int) string {
+	nota = max(0, min(5, nota))
+	return strings.Repeat("★", nota) + strings.Repeat("☆", 5-nota)
+}
@@ -0,0 +1,172 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"answer-comments/internal/app"
+	"answer-comments/internal/clock"
+	"answer-comments/internal/database"
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/models"
+	"answer-comments/internal/service"
+	yt "answer-comments/internal/youtube"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+func TestCrossSitePostRejected(t *testing.T) {
+	s, err := NewServer(nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := s.Handler()
+
+	tests := []struct {
+		name   string
+		path   string
+		header map[string]string
+		want   int
+	}{
+		{"cross-site publish", "/comments/abc/publish", map[string]string{"Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+		{"same-site publish", "/comments/abc/publish", map[string]string{"Sec-Fetch-Site": "same-site"}, http.StatusForbidden},
+		{"cross-site load", "/load", map[string]string{"Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+		{"foreign origin", "/comments/abc/skip", map[string]string{"Origin": "https://evil.example"}, http.StatusForbidden},
+		{"same-origin", "/comments/abc/skip", map[string]string{"Sec-Fetch-Site": "same-origin"}, http.StatusNotFound},
+		{"same origin header", "/comments/abc/skip", map[string]string{"Origin": "http://127.0.0.1:8080"}, http.StatusNotFound},
+		{"non-browser client", "/comments/abc/skip", nil, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8080"+tt.path, nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexLocale(t *testing.T) {
+	s, err := NewServer(nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := indexData{
+		Items: []*item{{
+			Pending: service.PendingComment{
+				Comment:     &youtube.Comment{Id: "c1", Snippet: &youtube.CommentSnippet{AuthorDisplayName: "Ana", TextOriginal: "Ótimo vídeo"}},
+				PublishedAt: time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC),
+				VideoTitle:  "Vídeo",
+				IsMember:    true,
+			},
+			Analysis:   models.SentimentAnalysis{Sentimento: "positivo", Nota: 5, Tema: "Elogio"},
+			Suggestion: service.Suggestion{Answer: "Valeu!", TranscriptLen: -1, AuthorHistory: 1, PastAnswers: 2},
+			Status:     statusPending,
+		}},
+		Pending: 1,
+		Loaded:  true,
+		Members: 3,
+	}
+
+	tests := []struct {
+		locale i18n.Locale
+		want   []string
+	}{
+		{i18n.PtBR, []string{`lang="pt-BR"`, "1 na fila · 0 revisados · 3 membros", "MEMBRO", "● positivo", "indisponível", "1 interação", "2 similares", "18/10/2026 às 12:00", ">Publicar<", ">Pular<"}},
+		{i18n.En, []string{`lang="en"`, "1 in the queue · 0 reviewed · 3 members", "MEMBER", "● positive", "unavailable", "1 interaction", "2 similar", "Oct 18, 2026 at 12:00", ">Publish<", ">Skip<"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.locale), func(t *testing.T) {
+			i18n.Set(tt.locale)
+			t.Cleanup(func() { i18n.Set(i18n.PtBR) })
+
+			var b strings.Builder
+			if err := s.tmpl.ExecuteTemplate(&b, "index.html", data); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(b.String(), want) {
+					t.Errorf("a página não contém %q", want)
+				}
+			}
+		})
+	}
+}
+
+// TestConcurrentPublish sends the same publish from several tabs at once;
+// run with -race. Only one of them may reach YouTube.
+func TestConcurrentPublish(t *testing.T) {
+	if err := database.InitDB(filepath.Join(t.TempDir(), "comments.db")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(database.CloseDB)
+
+	channel := yt.NewFakeChannel("UCcanal")
+	channel.AddVideo("v1", "Vídeo", "", "")
+	thread := channel.AddThread("v1", "c1", "Ana", "UCana", "Ótimo vídeo", time.Now())
+	svc := &service.CommentService{
+		App:   &app.App{Config: &app.Config{}, YT: channel, ChannelID: "UCcanal"},
+		Clock: clock.Real{},
+	}
+	s, err := NewServer(svc, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.items["c1"] = &item{
+		Pending:    service.PendingComment{Comment: thread.Snippet.TopLevelComment, VideoTitle: "Vídeo"},
+		Analysis:   models.SentimentAnalysis{Sentimento: "positivo", Nota: 5, Tema: "Elogio"},
+		Suggestion: service.Suggestion{Answer: "Valeu!"},
+		Status:     statusPending,
+	}
+	s.order = []string{"c1"}
+	h := s.Handler()
+
+	const tabs = 10
+	codes := make(chan int, tabs)
+	var wg sync.WaitGroup
+	for range tabs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			form := url.Values{"answer": {"Valeu!"}}
+			req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:8080/comments/c1/publish", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			codes <- rec.Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	published := 0
+	for code := range codes {
+		switch code {
+		case http.StatusSeeOther:
+			published++
+		case http.StatusNotFound:
+		default:
+			t.Errorf("status = %d", code)
+		}
+	}
+	if published != 1 {
+		t.Errorf("%d publicações aceitas, want 1", published)
+	}
+	if n := len(channel.Replies("c1")); n != 1 {
+		t.Errorf("%d respostas no canal, want 1", n)
+	}
+	if got := s.items["c1"].Status; got != statusPublished {
+		t.Errorf("status = %q, want %q", got, statusPublished)
+	}
+}
@@ -0,0 +1,277 @@
+// Package webhook delivers pipeline events as signed JSON POSTs to the URLs
+// configured by the user, with retries and a delivery log.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"answer-comments/internal/database"
+	"answer-comments/internal/logging"
+)
+
+var logger = logging.For(logging.Webhook)
+
+// Event types.
+const (
+	CommentReceived = "comment.received"
+	CommentAnalyzed = "comment.analyzed"
+	ReplyPublished  = "reply.published"
+	ReplySkipped    = "reply.skipped"
+	PipelineError   = "pipeline.error"
+)
+
+// Event is the JSON body POSTed to every webhook URL.
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	ChannelID string    `json:"channel_id"`
+	Data      Payload   `json:"data"`
+}
+
+// Payload carries the comment and decision details of an event. Fields that
+// do not apply to an event type are omitted.
+type Payload struct {
+	CommentID       string `json:"comment_id,omitempty"`
+	VideoID         string `json:"video_id,omitempty"`
+	VideoTitle      string `json:"video_title,omitempty"`
+	AuthorName      string `json:"author_name,omitempty"`
+	AuthorChannelID string `json:"author_channel_id,omitempty"`
+	Text            string `json:"text,omitempty"`
+	IsMember        bool   `json:"is_member,omitempty"`
+	Sentiment       string `json:"sentiment,omitempty"`
+	Score           int    `json:"score,omitempty"`
+	Theme           string `json:"theme,omitempty"`
+	Language        string `json:"language,omitempty"`
+	Answer          string `json:"answer,omitempty"`
+	Mode            string `json:"mode,omitempty"`   // reply.published: auto, accepted or edited
+	Reason          string `json:"reason,omitempty"` // reply.skipped
+	Error           string `json:"error,omitempty"`  // pipeline.error
+	ErrorKind       string `json:"error_kind,omitempty"`
+}
+
+// Dispatcher sends events asynchronously. A nil *Dispatcher is valid and
+// drops every event, so callers do not need to check whether webhooks are
+// configured. After Close, events are dropped too.
+type Dispatcher struct {
+	urls        []string
+	secret      string
+	events      map[string]bool // nil = every event
+	channelID   string
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+
+	// ctx is cancelled by Close when the pending deliveries do not finish
+	// in time, aborting their requests and retries
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu     sync.Mutex // guards closed and wg.Add against Close
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// Config configures the webhooks.
+type Config struct {
+	URLs   []string `yaml:"urls" env:"WEBHOOK_URLS"`
+	Secret string   `yaml:"secret" env:"WEBHOOK_SECRET" secret:"true"`
+	// Events filters the event types sent; empty sends every event.
+	Events []string `yaml:"events" env:"WEBHOOK_EVENTS"`
+}
+
+// EventTypes lists every event type, for validation.
+var EventTypes = []string{CommentReceived, CommentAnalyzed, ReplyPublished, ReplySkipped, PipelineError}
+
+// New creates a Dispatcher from cfg. It returns nil when no URL is
+// configured.
+func New(channelID string, cfg Config) *Dispatcher {
+	var urls []string
+	for _, u := range cfg.URLs {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+
+	d := &Dispatcher{
+		urls:        urls,
+		secret:      cfg.Secret,
+		channelID:   channelID,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: 5,
+		backoff:     time.Second,
+	}
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	if len(cfg.Events) > 0 {
+		d.events = make(map[string]bool)
+		for _, e := range cfg.Events {
+			d.events[strings.TrimSpace(e)] = true
+		}
+	}
+	if d.secret == "" {
+		logger.Warn("WEBHOOK_SECRET não configurado; os webhooks serão enviados sem assinatura")
+	}
+	return d
+}
+
+// Emit sends an event to every URL in the background.
+func (d *Dispatcher) Emit(eventType string, data Payload) {
+	if d == nil || (d.events != nil && !d.events[eventType]) {
+		return
+	}
+	event := Event{
+		ID:        newID(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		ChannelID: d.channelID,
+		Data:      data,
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		logger.Error("erro ao serializar evento", "event", eventType, logging.KeyError, err)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		logger.Debug("evento descartado após o encerramento", "event", eventType, "event_id", event.ID)
+		return
+	}
+	for _, url := range d.urls {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.deliver(url, event, body)
+		}()
+	}
+}
+
+// Close stops accepting events and waits up to timeout for pending
+// deliveries. Deliveries still running after that are cancelled; Close
+// returns once they have stopped, so none of them logs to the database after
+// it.
+func (d *Dispatcher) Close(timeout time.Duration) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+	defer d.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		logger.Warn("entregas de webhook pendentes foram abandonadas ao encerrar")
+		d.cancel()
+		<-done
+	}
+}
+
+// deliver POSTs the event, retrying with exponential backoff on network
+// errors, 429 and 5xx responses. Every attempt goes to the delivery log.
+func (d *Dispatcher) deliver(url string, event Event, body []byte) {
+	wait := d.backoff
+	for attempt := 1; attempt <= d.maxAttempts; attempt++ {
+		status, err := d.post(url, event, body)
+		if d.ctx.Err() != nil {
+			// Abandoned by Close: the attempt did not complete
+			return
+		}
+
+		delivery := database.WebhookDelivery{
+			EventID:    event.ID,
+			EventType:  event.Type,
+			URL:        url,
+			Attempt:    attempt,
+			StatusCode: status,
+		}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		if dbErr := database.SaveWebhookDelivery(delivery); dbErr != nil {
+			logger.Error("erro ao registrar entrega de webhook", "event_id", event.ID, logging.KeyError, dbErr)
+		}
+
+		logger.Debug("entrega de webhook", "url", url, "event", event.Type, "event_id", event.ID,
+			"attempt", attempt, "status", status, logging.KeyError, err)
+		if err == nil && status < 300 {
+			return
+		}
+		if err == nil && status != http.StatusTooManyRequests && status < 500 {
+			logger.Warn("webhook recusou o evento", "url", url, "event", event.Type, "event_id", event.ID, "status", status)
+			return
+		}
+		if attempt < d.maxAttempts {
+			select {
+			case <-time.After(wait):
+			case <-d.ctx.Done():
+				return
+			}
+			wait *= 2
+		}
+	}
+	logger.Error("evento não entregue", "url", url, "event", event.Type, "event_id", event.ID, "attempts", d.maxAttempts)
+}
+
+func (d *Dispatcher) post(url string, event Event, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "answer-comments-webhook")
+	req.Header.Set("X-Webhook-Id", event.ID)
+	req.Header.Set("X-Webhook-Event", event.Type)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	if d.secret != "" {
+		req.Header.Set("X-Webhook-Signature", "sha256="+Sign(d.secret, timestamp, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex HMAC-SHA256 of "timestamp.body" with secret. Receivers
+// recompute it to authenticate the event and reject replays by timestamp.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("evt_%d", time.Now().UnixNano())
+	}
+	return "evt_" + hex.EncodeToString(b)
+}
@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"answer-comments/internal/database"
+)
+
+// newTestDispatcher returns a Dispatcher posting to handler, with a fresh
+// delivery log and a short backoff.
+func newTestDispatcher(t *testing.T, handler http.HandlerFunc) *Dispatcher {
+	t.Helper()
+	if err := database.InitDB(filepath.Join(t.TempDir(), "comments.db")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(database.CloseDB)
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	d := New("UCcanal", Config{URLs: []string{srv.URL}, Secret: "segredo"})
+	d.backoff = time.Millisecond
+	return d
+}
+
+// deliveries returns the number of attempts in the delivery log.
+func deliveries(t *testing.T) int {
+	t.Helper()
+	counts, err := database.CountRows()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range counts {
+		if c.Table == "webhook_deliveries" {
+			return c.Rows
+		}
+	}
+	t.Fatal("tabela webhook_deliveries não encontrada")
+	return 0
+}
+
+func TestCloseWaitsForDeliveries(t *testing.T) {
+	d := newTestDispatcher(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+	})
+	d.Emit(ReplyPublished, Payload{CommentID: "c1"})
+	d.Close(5 * time.Second)
+
+	if n := deliveries(t); n != 1 {
+		t.Errorf("%d entregas registradas, want 1", n)
+	}
+}
+
+func TestCloseCancelsPendingDeliveries(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	d := newTestDispatcher(t, func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+	})
+	// Runs before the server is closed
+	t.Cleanup(func() { close(release) })
+	d.Emit(ReplyPublished, Payload{CommentID: "c1"})
+	<-started
+
+	begin := time.Now()
+	d.Close(50 * time.Millisecond)
+	if elapsed := time.Since(begin); elapsed > 5*time.Second {
+		t.Errorf("Close levou %s", elapsed)
+	}
+	// The cancelled attempt is not logged: the database may already be closed
+	if n := deliveries(t); n != 0 {
+		t.Errorf("%d entregas registradas após o encerramento, want 0", n)
+	}
+}
+
+func TestEmitAfterClose(t *testing.T) {
+	var requests atomic.Int32
+	d := newTestDispatcher(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	})
+	d.Close(time.Second)
+	d.Emit(ReplyPublished, Payload{CommentID: "c1"})
+	d.Close(time.Second)
+
+	if n := requests.Load(); n != 0 {
+		t.Errorf("%d eventos enviados após Close, want 0", n)
+	}
+}
+
+// TestEmitDuringClose runs Emit concurrently with Close; run with -race.
+func TestEmitDuringClose(t *testing.T) {
+	d := newTestDispatcher(t, func(w http.ResponseWriter, r *http.Request) {})
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.Emit(CommentReceived, Payload{CommentID: "c1"})
+		}()
+	}
+	d.Close(5 * time.Second)
+	wg.Wait()
+}
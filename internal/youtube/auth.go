@@ -0,0 +1,310 @@
+package youtube
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/logging"
+	"answer-comments/internal/secrets"
+	"answer-comments/internal/ui"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/youtube/v3"
+)
+
+// loginTimeout is how long the loopback server waits for the browser.
+const loginTimeout = 5 * time.Minute
+
+// revokeURL is Google's OAuth 2.0 token revocation endpoint.
+const revokeURL = "https://oauth2.googleapis.com/revoke"
+
+// tokeninfoURL is Google's endpoint that describes a valid access token; a
+// variable so tests can point it to a local server.
+var tokeninfoURL = "https://oauth2.googleapis.com/tokeninfo"
+
+// ErrTokenRevoked is returned by TokenSource when Google rejects the refresh
+// token (invalid_grant): the user revoked the access, the password changed or
+// the token expired. Only a new Login fixes it.
+var ErrTokenRevoked = errors.New("autorização revogada ou expirada; rode \"answer-comments auth login\"")
+
+// Scopes returns the OAuth scopes the application needs. Reading captions
+// (transcription) requires the full youtube scope.
+func Scopes(transcription bool) []string {
+	scopes := []string{YoutubeForceSslScope, YoutubeChannelMembershipsCreatorScope}
+	if transcription {
+		scopes = append(scopes, youtube.YoutubeScope)
+	}
+	return scopes
+}
+
+// Login authorizes the application in the browser and saves the token to
+// tokFile. It uses the loopback redirect flow for desktop apps: a one-shot
+// server on a random 127.0.0.1 port receives the code, the state is checked
+// against a random value and the exchange is bound to a PKCE verifier.
+func Login(ctx context.Context, config *oauth2.Config, tokFile string) (*oauth2.Token, error) {
+	tok, err := loginLoopback(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if err := saveYoutubeToken(tokFile, tok); err != nil {
+		return nil, fmt.Errorf("erro ao salvar token: %w", err)
+	}
+	return tok, nil
+}
+
+// Logout revokes the token saved in tokFile at Google and deletes it.
+// The file is deleted even when the revocation fails (e.g. the token was
+// already revoked).
+func Logout(ctx context.Context, tokFile string) error {
+	tok, err := LoadToken(tokFile)
+	if err != nil {
+		return err
+	}
+	revokeErr := revokeToken(ctx, tok)
+	if err := secrets.Remove(tokFile); err != nil {
+		return err
+	}
+	return revokeErr
+}
+
+// LoadToken reads the token saved by Login.
+func LoadToken(tokFile string) (*oauth2.Token, error) {
+	return tokenFromFile(tokFile)
+}
+
+// SaveToken writes tok to tokFile with the current secrets settings (e.g. to
+// encrypt a token saved in plain text or move it to the keyring).
+func SaveToken(tokFile string, tok *oauth2.Token) error {
+	return writeTokenFile(tokFile, tok)
+}
+
+// TokenSource returns a token source for tok that writes every refreshed
+// token to tokFile and reports a rejected refresh token as ErrTokenRevoked.
+func TokenSource(ctx context.Context, config *oauth2.Config, tokFile string, tok *oauth2.Token) oauth2.TokenSource {
+	return &persistingTokenSource{src: config.TokenSource(ctx, tok), file: tokFile, last: tok.AccessToken}
+}
+
+// CheckToken asks Google whether tok is still accepted and returns the token
+// to use from then on. A cached access token says nothing about a revoked
+// grant, so a token with a refresh token is refreshed even when it has not
+// expired (the new one is written to tokFile); one without is checked at the
+// tokeninfo endpoint. A rejected token is reported as ErrTokenRevoked.
+func CheckToken(ctx context.Context, config *oauth2.Config, tokFile string, tok *oauth2.Token) (*oauth2.Token, error) {
+	if tok.RefreshToken == "" {
+		if err := checkAccessToken(ctx, tok); err != nil {
+			return nil, err
+		}
+		return tok, nil
+	}
+	expired := *tok
+	expired.Expiry = time.Now().Add(-time.Minute)
+	return TokenSource(ctx, config, tokFile, &expired).Token()
+}
+
+// checkAccessToken looks tok up at the tokeninfo endpoint, which rejects
+// revoked and expired access tokens with HTTP 400.
+func checkAccessToken(ctx context.Context, tok *oauth2.Token) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokeninfoURL, strings.NewReader(url.Values{"access_token": {tok.AccessToken}}.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := contextClient(ctx).Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao validar o token: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusBadRequest:
+		return ErrTokenRevoked
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("erro ao validar o token: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// contextClient returns the HTTP client set in ctx with oauth2.HTTPClient, as
+// the oauth2 package does, or the default client.
+func contextClient(ctx context.Context) *http.Client {
+	if c, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+		return c
+	}
+	return http.DefaultClient
+}
+
+type persistingTokenSource struct {
+	src  oauth2.TokenSource
+	file string
+
+	mu   sync.Mutex
+	last string // access token currently in file
+}
+
+func (s *persistingTokenSource) Token() (*oauth2.Token, error) {
+	tok, err := s.src.Token()
+	if err != nil {
+		var re *oauth2.RetrieveError
+		if errors.As(err, &re) && re.ErrorCode == "invalid_grant" {
+			return nil, fmt.Errorf("%w: %w", ErrTokenRevoked, err)
+		}
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tok.AccessToken != s.last {
+		// A failed write only costs an extra refresh on the next run.
+		if err := writeTokenFile(s.file, tok); err != nil {
+			logger.Warn("não foi possível salvar o token renovado", "file", s.file, logging.KeyError, err)
+		} else {
+			s.last = tok.AccessToken
+		}
+	}
+	return tok, nil
+}
+
+type callbackResult struct {
+	code string
+	err  error
+}
+
+func loginLoopback(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível abrir o servidor local de autorização: %w", err)
+	}
+	defer listener.Close()
+
+	cfg := *config
+	cfg.RedirectURL = fmt.Sprintf("http://%s/callback", listener.Addr())
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	results := make(chan callbackResult, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /callback", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		// Requests with a wrong state are not ours (or are forged): answer
+		// them but keep waiting for the real redirect.
+		if subtle.ConstantTimeCompare([]byte(q.Get("state")), []byte(state)) != 1 {
+			http.Error(w, "parâmetro state inválido", http.StatusBadRequest)
+			return
+		}
+		res := readCallback(q)
+		if res.err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "<p>%s</p>", html.EscapeString(i18n.T("auth.callback_failed", res.err)))
+		} else {
+			fmt.Fprintf(w, "<p>%s</p>", html.EscapeString(i18n.T("auth.callback_done")))
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := cfg.AuthCodeURL(state,
+		oauth2.AccessTypeOffline,
+		oauth2.ApprovalForce, // always return a refresh token
+		oauth2.S256ChallengeOption(verifier),
+	)
+	ui.Info(i18n.T("auth.open_browser", authURL))
+	openBrowser(authURL)
+
+	ctx, cancel := context.WithTimeout(ctx, loginTimeout)
+	defer cancel()
+	var res callbackResult
+	select {
+	case res = <-results:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("autorização não concluída: %w", ctx.Err())
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	tok, err := cfg.Exchange(ctx, res.code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível obter o token a partir do código: %w", err)
+	}
+	return tok, nil
+}
+
+// readCallback extracts the authorization code from the redirect.
+func readCallback(q url.Values) callbackResult {
+	if e := q.Get("error"); e != "" {
+		return callbackResult{err: fmt.Errorf("autorização negada: %s", e)}
+	}
+	code := q.Get("code")
+	if code == "" {
+		return callbackResult{err: errors.New("código de autorização ausente")}
+	}
+	return callbackResult{code: code}
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// openBrowser tries to open url in the default browser; failures are ignored
+// since the URL is also printed.
+func openBrowser(url string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err == nil {
+		go cmd.Wait()
+	}
+}
+
+func revokeToken(ctx context.Context, tok *oauth2.Token) error {
+	token := tok.RefreshToken
+	if token == "" {
+		token = tok.AccessToken
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, revokeURL, strings.NewReader(url.Values{"token": {token}}.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("erro ao revogar o token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("erro ao revogar o token: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
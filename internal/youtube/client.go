@@ -0,0 +1,125 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// Moderation statuses accepted by Client.Moderate.
+const (
+	ModerationPublished     = "published"
+	ModerationHeldForReview = "heldForReview"
+	ModerationRejected      = "rejected"
+)
+
+// ErrNotFound is returned when a video or comment does not exist.
+var ErrNotFound = errors.New("não encontrado")
+
+// Client is the subset of the YouTube Data API used by the application. The
+// real implementation is APIClient; FakeChannel is an in-memory stand-in.
+type Client interface {
+	// ListThreads returns one page of comment threads related to the
+	// channel, newest first. An empty pageToken requests the first page.
+	ListThreads(ctx context.Context, channelID string, pageToken string, maxResults int64) (*youtube.CommentThreadListResponse, error)
+	// GetThread returns a single comment thread by the ID of its top-level
+	// comment.
+	GetThread(ctx context.Context, threadID string) (*youtube.CommentThread, error)
+	// GetVideo returns the snippet of a video.
+	GetVideo(ctx context.Context, videoID string) (*youtube.Video, error)
+	// GetTranscript returns the cleaned transcript of a video, preferring
+	// the caption track in lang (an ISO 639-1 code; "" for no preference).
+	GetTranscript(ctx context.Context, videoID string, lang string) (string, error)
+	// PublishReply posts text as a reply to the top-level comment parentID.
+	PublishReply(ctx context.Context, parentID string, text string) error
+	// Moderate sets the moderation status of a comment.
+	Moderate(ctx context.Context, commentID string, status string) error
+	// ListMembers returns one page of the channel's current members. It
+	// needs the channel-memberships.creator scope and a channel with
+	// memberships enabled.
+	ListMembers(ctx context.Context, pageToken string) (*youtube.MemberListResponse, error)
+	// ListMembershipsLevels returns the channel's membership levels.
+	ListMembershipsLevels(ctx context.Context) (*youtube.MembershipsLevelListResponse, error)
+}
+
+// APIClient implements Client on top of the YouTube Data API service.
+type APIClient struct {
+	Service *youtube.Service
+}
+
+// NewAPIClient wraps an authenticated YouTube service.
+func NewAPIClient(service *youtube.Service) *APIClient {
+	return &APIClient{Service: service}
+}
+
+func (c *APIClient) ListThreads(ctx context.Context, channelID string, pageToken string, maxResults int64) (*youtube.CommentThreadListResponse, error) {
+	resp, err := c.Service.CommentThreads.List([]string{"snippet,replies"}).
+		AllThreadsRelatedToChannelId(channelID).
+		Order("time").
+		PageToken(pageToken).
+		MaxResults(maxResults).
+		Context(ctx).
+		Do()
+	Track("commentThreads.list", err)
+	return resp, err
+}
+
+func (c *APIClient) GetThread(ctx context.Context, threadID string) (*youtube.CommentThread, error) {
+	resp, err := c.Service.CommentThreads.List([]string{"snippet,replies"}).Id(threadID).Context(ctx).Do()
+	Track("commentThreads.list", err)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Items) == 0 {
+		return nil, fmt.Errorf("comentário %s: %w", threadID, ErrNotFound)
+	}
+	return resp.Items[0], nil
+}
+
+func (c *APIClient) GetVideo(ctx context.Context, videoID string) (*youtube.Video, error) {
+	resp, err := c.Service.Videos.List([]string{"snippet"}).Id(videoID).Context(ctx).Do()
+	Track("videos.list", err)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Items) == 0 {
+		return nil, fmt.Errorf("vídeo %s: %w", videoID, ErrNotFound)
+	}
+	return resp.Items[0], nil
+}
+
+func (c *APIClient) GetTranscript(ctx context.Context, videoID string, lang string) (string, error) {
+	return GetVideoTranscription(ctx, c.Service, videoID, lang)
+}
+
+func (c *APIClient) PublishReply(ctx context.Context, parentID string, text string) error {
+	return PublishComment(c.Service, parentID, text)
+}
+
+func (c *APIClient) Moderate(ctx context.Context, commentID string, status string) error {
+	err := c.Service.Comments.SetModerationStatus([]string{commentID}, status).Context(ctx).Do()
+	Track("comments.setModerationStatus", err)
+	if err != nil {
+		return fmt.Errorf("erro ao moderar comentário: %w", err)
+	}
+	return nil
+}
+
+func (c *APIClient) ListMembers(ctx context.Context, pageToken string) (*youtube.MemberListResponse, error) {
+	resp, err := c.Service.Members.List([]string{"snippet"}).
+		Mode("all_current").
+		MaxResults(1000).
+		PageToken(pageToken).
+		Context(ctx).
+		Do()
+	Track("members.list", err)
+	return resp, err
+}
+
+func (c *APIClient) ListMembershipsLevels(ctx context.Context) (*youtube.MembershipsLevelListResponse, error) {
+	resp, err := c.Service.MembershipsLevels.List([]string{"id,snippet"}).Context(ctx).Do()
+	Track("membershipsLevels.list", err)
+	return resp, err
+}
@@ -0,0 +1,310 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"answer-comments/internal/clock"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/youtube/v3"
+)
+
+// FakeChannel is an in-memory Client seeded with videos and comment threads.
+// It lets the service layer run without the YouTube API: replies published
+// through it show up in later ListThreads calls, like on the real channel.
+// Every method returns copies, so callers cannot change the channel's state
+// through them.
+type FakeChannel struct {
+	ChannelID string
+	// Clock dates the replies published through PublishReply (wall clock by
+	// default); tests share it with the service under test.
+	Clock clock.Clock
+
+	mu          sync.Mutex
+	videos      map[string]*youtube.Video
+	transcripts map[string]string
+	threads     []*youtube.CommentThread
+	moderation  map[string]string
+	nextID      int
+	// members is nil until AddMember is called; until then the Members API
+	// answers as for a channel without memberships.
+	members []*youtube.Member
+}
+
+// NewFakeChannel creates an empty fake channel owned by channelID.
+func NewFakeChannel(channelID string) *FakeChannel {
+	return &FakeChannel{
+		ChannelID:   channelID,
+		Clock:       clock.Real{},
+		videos:      make(map[string]*youtube.Video),
+		transcripts: make(map[string]string),
+		moderation:  make(map[string]string),
+	}
+}
+
+// AddVideo seeds a video. An empty transcript makes GetTranscript fail, as
+// for videos without automatic captions.
+func (f *FakeChannel) AddVideo(videoID, title, description, transcript string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.videos[videoID] = &youtube.Video{
+		Id:      videoID,
+		Snippet: &youtube.VideoSnippet{ChannelId: f.ChannelID, Title: title, Description: description},
+	}
+	if transcript != "" {
+		f.transcripts[videoID] = transcript
+	}
+}
+
+// AddThread seeds a top-level comment and returns its thread.
+func (f *FakeChannel) AddThread(videoID, commentID, authorName, authorChannelID, text string, publishedAt time.Time) *youtube.CommentThread {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	thread := &youtube.CommentThread{
+		Id: commentID,
+		Snippet: &youtube.CommentThreadSnippet{
+			ChannelId: f.ChannelID,
+			VideoId:   videoID,
+			TopLevelComment: &youtube.Comment{
+				Id:      commentID,
+				Snippet: newSnippet(videoID, authorName, authorChannelID, text, "", publishedAt),
+			},
+		},
+	}
+	f.threads = append(f.threads, thread)
+	// Same order as the API with Order("time"): newest first
+	sort.SliceStable(f.threads, func(i, j int) bool {
+		return f.threads[i].Snippet.TopLevelComment.Snippet.PublishedAt > f.threads[j].Snippet.TopLevelComment.Snippet.PublishedAt
+	})
+	return clone(thread)
+}
+
+// AddReply seeds a reply to a thread, e.g. one already written by the
+// channel owner in YouTube Studio.
+func (f *FakeChannel) AddReply(threadID, authorName, authorChannelID, text string, publishedAt time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addReply(threadID, authorName, authorChannelID, text, publishedAt)
+}
+
+// Replies returns the replies of a thread, oldest first.
+func (f *FakeChannel) Replies(threadID string) []*youtube.Comment {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	thread := f.thread(threadID)
+	if thread == nil || thread.Replies == nil {
+		return nil
+	}
+	return cloneAll(thread.Replies.Comments)
+}
+
+// ModerationStatus returns the status set by Moderate, or "" if none.
+func (f *FakeChannel) ModerationStatus(commentID string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.moderation[commentID]
+}
+
+func (f *FakeChannel) ListThreads(ctx context.Context, channelID string, pageToken string, maxResults int64) (*youtube.CommentThreadListResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if channelID != f.ChannelID {
+		return &youtube.CommentThreadListResponse{}, nil
+	}
+	start := 0
+	if pageToken != "" {
+		var err error
+		start, err = strconv.Atoi(pageToken)
+		if err != nil || start < 0 || start > len(f.threads) {
+			return nil, fmt.Errorf("pageToken inválido: %q", pageToken)
+		}
+	}
+	if maxResults <= 0 {
+		maxResults = 20
+	}
+	end := min(start+int(maxResults), len(f.threads))
+
+	resp := &youtube.CommentThreadListResponse{Items: cloneAll(f.threads[start:end])}
+	if end < len(f.threads) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	return resp, nil
+}
+
+func (f *FakeChannel) GetThread(ctx context.Context, threadID string) (*youtube.CommentThread, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	thread := f.thread(threadID)
+	if thread == nil {
+		return nil, fmt.Errorf("comentário %s: %w", threadID, ErrNotFound)
+	}
+	return clone(thread), nil
+}
+
+func (f *FakeChannel) GetVideo(ctx context.Context, videoID string) (*youtube.Video, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	video, ok := f.videos[videoID]
+	if !ok {
+		return nil, fmt.Errorf("vídeo %s: %w", videoID, ErrNotFound)
+	}
+	return clone(video), nil
+}
+
+// GetTranscript ignores lang: each fake video has a single transcript.
+func (f *FakeChannel) GetTranscript(ctx context.Context, videoID string, lang string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	transcript, ok := f.transcripts[videoID]
+	if !ok {
+		return "", fmt.Errorf("nenhuma legenda automática encontrada para este vídeo")
+	}
+	return transcript, nil
+}
+
+func (f *FakeChannel) PublishReply(ctx context.Context, parentID string, text string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addReply(parentID, "Canal", f.ChannelID, text, f.Clock.Now())
+}
+
+func (f *FakeChannel) Moderate(ctx context.Context, commentID string, status string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch status {
+	case ModerationPublished, ModerationHeldForReview, ModerationRejected:
+	default:
+		return fmt.Errorf("status de moderação inválido: %q", status)
+	}
+	if f.thread(commentID) == nil {
+		return fmt.Errorf("comentário %s: %w", commentID, ErrNotFound)
+	}
+	f.moderation[commentID] = status
+	return nil
+}
+
+// AddMember seeds a channel member at level.
+func (f *FakeChannel) AddMember(channelID, name, level string, since time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.members = append(f.members, &youtube.Member{
+		Snippet: &youtube.MemberSnippet{
+			CreatorChannelId: f.ChannelID,
+			MemberDetails:    &youtube.ChannelProfileDetails{ChannelId: channelID, DisplayName: name},
+			MembershipsDetails: &youtube.MembershipsDetails{
+				HighestAccessibleLevel:            level,
+				HighestAccessibleLevelDisplayName: level,
+				MembershipsDuration: &youtube.MembershipsDuration{
+					MemberSince: since.Format(time.RFC3339),
+				},
+			},
+		},
+	})
+}
+
+func (f *FakeChannel) ListMembers(ctx context.Context, pageToken string) (*youtube.MemberListResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.members == nil {
+		return nil, membershipsDisabled()
+	}
+	return &youtube.MemberListResponse{Items: cloneAll(f.members)}, nil
+}
+
+func (f *FakeChannel) ListMembershipsLevels(ctx context.Context) (*youtube.MembershipsLevelListResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.members == nil {
+		return nil, membershipsDisabled()
+	}
+	return &youtube.MembershipsLevelListResponse{}, nil
+}
+
+func membershipsDisabled() error {
+	return &googleapi.Error{
+		Code:    http.StatusForbidden,
+		Message: "The channel does not have memberships enabled.",
+		Errors:  []googleapi.ErrorItem{{Reason: "channelMembershipsNotEnabled"}},
+	}
+}
+
+// addReply must be called with f.mu held.
+func (f *FakeChannel) addReply(threadID, authorName, authorChannelID, text string, publishedAt time.Time) error {
+	thread := f.thread(threadID)
+	if thread == nil {
+		return fmt.Errorf("comentário %s: %w", threadID, ErrNotFound)
+	}
+	if thread.Replies == nil {
+		thread.Replies = &youtube.CommentThreadReplies{}
+	}
+	f.nextID++
+	reply := &youtube.Comment{
+		Id:      fmt.Sprintf("%s.reply%d", threadID, f.nextID),
+		Snippet: newSnippet(thread.Snippet.VideoId, authorName, authorChannelID, text, threadID, publishedAt),
+	}
+	thread.Replies.Comments = append(thread.Replies.Comments, reply)
+	thread.Snippet.TotalReplyCount++
+	return nil
+}
+
+// thread must be called with f.mu held.
+func (f *FakeChannel) thread(id string) *youtube.CommentThread {
+	for _, t := range f.threads {
+		if t.Id == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func newSnippet(videoID, authorName, authorChannelID, text, parentID string, publishedAt time.Time) *youtube.CommentSnippet {
+	return &youtube.CommentSnippet{
+		VideoId:           videoID,
+		ParentId:          parentID,
+		AuthorDisplayName: authorName,
+		AuthorChannelId:   &youtube.CommentSnippetAuthorChannelId{Value: authorChannelID},
+		TextOriginal:      text,
+		TextDisplay:       text,
+		PublishedAt:       publishedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// clone returns a deep copy of v. The API types are plain JSON documents, so
+// a round trip copies every nested pointer.
+func clone[T any](v *T) *T {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	c := new(T)
+	if err := json.Unmarshal(b, c); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func cloneAll[T any](list []*T) []*T {
+	copies := make([]*T, len(list))
+	for i, v := range list {
+		copies[i] = clone(v)
+	}
+	return copies
+}
@@ -0,0 +1,45 @@
+package youtube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+func TestFakeChannelReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	f := NewFakeChannel("UCcanal")
+	f.AddVideo("v1", "Título", "Descrição", "")
+	added := f.AddThread("v1", "c1", "Ana", "UCana", "Ótimo vídeo", time.Now())
+	added.Snippet.TopLevelComment.Snippet.TextOriginal = "alterado por AddThread"
+
+	thread, err := f.GetThread(ctx, "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	thread.Snippet.TopLevelComment.Snippet.TextOriginal = "alterado por GetThread"
+	thread.Replies = &youtube.CommentThreadReplies{Comments: []*youtube.Comment{{Snippet: &youtube.CommentSnippet{}}}}
+
+	video, err := f.GetVideo(ctx, "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	video.Snippet.Title = "alterado"
+
+	list, err := f.ListThreads(ctx, "UCcanal", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := list.Items[0]; got.Snippet.TopLevelComment.Snippet.TextOriginal != "Ótimo vídeo" || got.Replies != nil {
+		t.Errorf("os valores retornados alteraram o canal: %q, replies %v", got.Snippet.TopLevelComment.Snippet.TextOriginal, got.Replies)
+	}
+	list.Items[0].Snippet.VideoId = "outro"
+	if again, _ := f.ListThreads(ctx, "UCcanal", "", 10); again.Items[0].Snippet.VideoId != "v1" {
+		t.Errorf("alterar o retorno de ListThreads mudou o canal")
+	}
+	if video, _ := f.GetVideo(ctx, "v1"); video.Snippet.Title != "Título" {
+		t.Errorf("título = %q, want Título", video.Snippet.Title)
+	}
+}
@@ -0,0 +1,75 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"answer-comments/internal/models"
+
+	"google.golang.org/api/googleapi"
+)
+
+// ErrMembersUnavailable is returned by FetchMembers when the channel has no
+// memberships or the credentials cannot read them: the Members API is only
+// open to channels approved by YouTube.
+var ErrMembersUnavailable = errors.New("API de membros indisponível para este canal")
+
+// FetchMembers returns every current member of the channel with its level and
+// membership start.
+func FetchMembers(ctx context.Context, c Client) ([]models.Member, error) {
+	levels := make(map[string]string)
+	resp, err := c.ListMembershipsLevels(ctx)
+	if err != nil {
+		return nil, classifyMembersError(err)
+	}
+	for _, l := range resp.Items {
+		if l.Snippet != nil && l.Snippet.LevelDetails != nil {
+			levels[l.Id] = l.Snippet.LevelDetails.DisplayName
+		}
+	}
+
+	var members []models.Member
+	var pageToken string
+	for {
+		page, err := c.ListMembers(ctx, pageToken)
+		if err != nil {
+			return nil, classifyMembersError(err)
+		}
+		for _, item := range page.Items {
+			if item.Snippet == nil || item.Snippet.MemberDetails == nil {
+				continue
+			}
+			m := models.Member{
+				ChannelID: item.Snippet.MemberDetails.ChannelId,
+				Name:      item.Snippet.MemberDetails.DisplayName,
+			}
+			if d := item.Snippet.MembershipsDetails; d != nil {
+				m.Level = d.HighestAccessibleLevelDisplayName
+				if m.Level == "" {
+					m.Level = levels[d.HighestAccessibleLevel]
+				}
+				if d.MembershipsDuration != nil {
+					m.Since, _ = time.Parse(time.RFC3339, d.MembershipsDuration.MemberSince)
+				}
+			}
+			members = append(members, m)
+		}
+		if page.NextPageToken == "" {
+			return members, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// classifyMembersError wraps 403 answers (memberships disabled, missing
+// scope or approval) with ErrMembersUnavailable.
+func classifyMembersError(err error) error {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden {
+		return fmt.Errorf("%w: %w", ErrMembersUnavailable, err)
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+package youtube
+
+import (
+	"answer-comments/internal/logging"
+	"answer-comments/internal/metrics"
+)
+
+var logger = logging.For(logging.YouTube)
+
+// quotaCost is the YouTube Data API quota cost of each method used by the
+// application, per https://developers.google.com/youtube/v3/determine_quota_cost
+var quotaCost = map[string]int{
+	"channels.list":                1,
+	"commentThreads.list":          1,
+	"videos.list":                  1,
+	"captions.list":                50,
+	"captions.download":            200,
+	"comments.insert":              50,
+	"comments.setModerationStatus": 50,
+	"members.list":                 1,
+	"membershipsLevels.list":       1,
+}
+
+// QuotaCost returns the quota units charged for one call to method.
+func QuotaCost(method string) int {
+	return quotaCost[method]
+}
+
+// Track records a YouTube Data API call in the metrics. Failed calls are
+// charged too: the API bills quota for rejected requests.
+func Track(method string, err error) {
+	metrics.YouTubeCall(method, quotaCost[method], err)
+	if err != nil {
+		logger.Warn("chamada à YouTube Data API falhou", "method", method, logging.KeyError, err)
+		return
+	}
+	logger.Debug("chamada à YouTube Data API", "method", method, "quota_units", quotaCost[method])
+}
@@ -3,11 +3,18 @@ package youtube
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
 
+	"answer-comments/internal/i18n"
+	"answer-comments/internal/language"
+	"answer-comments/internal/logging"
+	"answer-comments/internal/secrets"
+	"answer-comments/internal/ui"
+
 	"golang.org/x/oauth2"
 	"google.golang.org/api/youtube/v3"
 )
@@ -19,65 +26,71 @@ const (
 	YoutubeChannelMembershipsCreatorScope = youtube.YoutubeChannelMembershipsCreatorScope
 )
 
-// tokenFromFile reads a token from a file path.
+// tokenFromFile reads a token from a file path (or the keyring entry that
+// replaces it), decrypting it when needed.
 func tokenFromFile(file string) (*oauth2.Token, error) {
-	f, err := os.Open(file)
+	data, err := secrets.ReadFile(file)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
 	tok := &oauth2.Token{}
-	err = json.NewDecoder(f).Decode(tok)
+	err = json.Unmarshal(data, tok)
 	return tok, err
 }
 
-// GetYoutubeClient uses a Context and Config to retrieve a Token
-// then generate a Client. It returns the generated Client.
-func GetYoutubeClient(config *oauth2.Config) (*http.Client, error) {
-	tokFile := os.Getenv("TOKEN_FILE")
-	if tokFile == "" {
-		tokFile = "data/token.json"
-	}
+// GetYoutubeClient returns an HTTP client authorized with the token saved in
+// tokFile, running the browser login (Login) first when there is none or when
+// Google no longer accepts it (revoked or expired refresh token). Refreshed
+// tokens are written back to tokFile. A token that cannot be read (wrong
+// passphrase, missing key, corrupt file) is an error: logging in again would
+// overwrite it.
+// The context is kept by the client for token refreshes; an oauth2.HTTPClient
+// value in it sets the base transport.
+func GetYoutubeClient(ctx context.Context, config *oauth2.Config, tokFile string) (*http.Client, error) {
 	tok, err := tokenFromFile(tokFile)
-	if err != nil {
-		tok, err = getYoutubeTokenFromWeb(config)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		tok, err = Login(ctx, config, tokFile)
 		if err != nil {
 			return nil, fmt.Errorf("erro ao obter token da web: %w", err)
 		}
-		if err := saveYoutubeToken(tokFile, tok); err != nil {
-			return nil, fmt.Errorf("erro ao salvar token: %w", err)
+	case err != nil:
+		return nil, fmt.Errorf("erro ao ler o token salvo em %s: %w", tokFile, err)
+	default:
+		// Check the saved token with Google now, so a revoked grant is caught
+		// before the session starts instead of failing the first API call.
+		tok, err = CheckToken(ctx, config, tokFile, tok)
+		switch {
+		case errors.Is(err, ErrTokenRevoked):
+			ui.Warning(i18n.T("auth.reauthorize"))
+			tok, err = Login(ctx, config, tokFile)
+			if err != nil {
+				return nil, fmt.Errorf("erro ao obter token da web: %w", err)
+			}
+		case err != nil:
+			return nil, fmt.Errorf("erro ao renovar o token: %w", err)
 		}
 	}
-	return config.Client(context.Background(), tok), nil
+	return oauth2.NewClient(ctx, TokenSource(ctx, config, tokFile, tok)), nil
 }
 
 // saveToken to save a token to a file path.
 func saveYoutubeToken(path string, token *oauth2.Token) error {
-	fmt.Printf("Salvando o arquivo de credenciais em: %s\n", path)
-	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
-	if err != nil {
+	ui.Info(i18n.T("auth.saving_token", path))
+	if err := writeTokenFile(path, token); err != nil {
 		return fmt.Errorf("não foi possível salvar o token: %w", err)
 	}
-	defer f.Close()
-	return json.NewEncoder(f).Encode(token)
+	return nil
 }
 
-// getTokenFromWeb uses the OAuth2 config to request a token from the web, then returns the retrieved token.
-func getYoutubeTokenFromWeb(config *oauth2.Config) (*oauth2.Token, error) {
-	authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
-	fmt.Printf("Acesse esta URL no seu navegador para autorizar o aplicativo: \n%v\n", authURL)
-	fmt.Printf("\nApós autorizar, cole o código de autorização aqui e pressione Enter: ")
-
-	var authCode string
-	if _, err := fmt.Scan(&authCode); err != nil {
-		return nil, fmt.Errorf("não foi possível ler o código de autorização: %w", err)
-	}
-
-	tok, err := config.Exchange(context.TODO(), authCode)
+// writeTokenFile saves the token through the secrets layer: atomically
+// replaced and encrypted when a key is configured, or in the OS keyring.
+func writeTokenFile(path string, token *oauth2.Token) error {
+	data, err := json.Marshal(token)
 	if err != nil {
-		return nil, fmt.Errorf("não foi possível obter o token a partir do código: %w", err)
+		return err
 	}
-	return tok, nil
+	return secrets.WriteFile(path, append(data, '\n'))
 }
 
 // PublishComment posts a reply to a YouTube comment
@@ -93,35 +106,49 @@ func PublishComment(service *youtube.Service, parentId string, text string) erro
 	// O canal que responde é o autenticado.
 	call := service.Comments.Insert([]string{"snippet"}, comment)
 	_, err := call.Do()
+	Track("comments.insert", err)
 	if err != nil {
-		return fmt.Errorf("erro ao publicar resposta: %v", err)
+		return fmt.Errorf("erro ao publicar resposta: %w", err)
 	}
 	return nil
 }
 
-// GetVideoTranscription fetches the automatic caption/transcript for a video if available
-func GetVideoTranscription(ctx context.Context, service *youtube.Service, videoId string) (string, error) {
+// GetVideoTranscription fetches the caption/transcript for a video if available.
+// A track in lang (the commenter's language) is preferred; otherwise the
+// automatic caption is used.
+func GetVideoTranscription(ctx context.Context, service *youtube.Service, videoId string, lang string) (string, error) {
 	// List all available captions for the video
 	captionsListCall := service.Captions.List([]string{"snippet"}, videoId)
 	captionsListResponse, err := captionsListCall.Do()
+	Track("captions.list", err)
 	if err != nil {
 		return "", fmt.Errorf("erro ao listar legendas: %w", err)
 	}
 
-	// Find an automatic caption (prefer Portuguese, then English, then any)
+	// Find a caption in the requested language (prefer one written by the
+	// channel), else an automatic caption (prefer Portuguese, then English,
+	// then any)
 	var captionId string
-	var captionPriority int // 3 = pt, 2 = en, 1 = other
+	var captionPriority int // 5 = lang, 4 = lang automatic, 3 = pt, 2 = en, 1 = other
 
 	for _, caption := range captionsListResponse.Items {
-		if caption.Snippet.TrackKind != "asr" { // asr = automatic speech recognition
+		captionLang := language.Normalize(caption.Snippet.Language)
+		automatic := caption.Snippet.TrackKind == "asr" // asr = automatic speech recognition
+
+		var priority int
+		switch {
+		case lang != "" && captionLang == lang && !automatic:
+			priority = 5
+		case lang != "" && captionLang == lang:
+			priority = 4
+		case !automatic:
 			continue
-		}
-
-		priority := 1
-		if caption.Snippet.Language == "pt" || caption.Snippet.Language == "pt-BR" {
+		case captionLang == language.Portuguese:
 			priority = 3
-		} else if caption.Snippet.Language == "en" {
+		case captionLang == language.English:
 			priority = 2
+		default:
+			priority = 1
 		}
 
 		if priority > captionPriority {
@@ -130,6 +157,7 @@ func GetVideoTranscription(ctx context.Context, service *youtube.Service, videoI
 		}
 	}
 
+	logger.Debug("legenda escolhida", logging.KeyVideoID, videoId, "caption_id", captionId, "priority", captionPriority, "lang", lang)
 	if captionId == "" {
 		return "", fmt.Errorf("nenhuma legenda automática encontrada para este vídeo")
 	}
@@ -137,6 +165,7 @@ func GetVideoTranscription(ctx context.Context, service *youtube.Service, videoI
 	// Download the caption
 	captionDownloadCall := service.Captions.Download(captionId).Tfmt("srt")
 	resp, err := captionDownloadCall.Download()
+	Track("captions.download", err)
 	if err != nil {
 		return "", fmt.Errorf("erro ao baixar legenda: %w", err)
 	}
@@ -0,0 +1,119 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"answer-comments/internal/secrets"
+
+	"golang.org/x/oauth2"
+)
+
+// An unreadable token must be reported, not replaced by a new browser login
+// that would overwrite it.
+func TestGetYoutubeClientKeepsUnreadableToken(t *testing.T) {
+	if err := secrets.Setup(secrets.Options{}); err != nil {
+		t.Fatal(err)
+	}
+	tokFile := filepath.Join(t.TempDir(), "token.json")
+	const encrypted = "enc:v1:AAAA\n"
+	if err := os.WriteFile(tokFile, []byte(encrypted), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := GetYoutubeClient(context.Background(), &oauth2.Config{}, tokFile)
+	if !errors.Is(err, secrets.ErrNoKey) {
+		t.Fatalf("err = %v, want ErrNoKey", err)
+	}
+	data, _ := os.ReadFile(tokFile)
+	if string(data) != encrypted {
+		t.Errorf("token file changed to %q", data)
+	}
+}
+
+func TestCheckToken(t *testing.T) {
+	if err := secrets.Setup(secrets.Options{}); err != nil {
+		t.Fatal(err)
+	}
+	valid := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		tok       oauth2.Token
+		status    int    // of the token or tokeninfo endpoint
+		body      string // of the token endpoint
+		want      string // access token returned and saved
+		wantErr   error
+		otherFail bool
+	}{
+		{"refreshed before expiry", oauth2.Token{AccessToken: "old", RefreshToken: "r", Expiry: valid},
+			http.StatusOK, `{"access_token":"new","token_type":"Bearer","expires_in":3600}`, "new", nil, false},
+		{"revoked grant with a cached access token", oauth2.Token{AccessToken: "old", RefreshToken: "r", Expiry: valid},
+			http.StatusBadRequest, `{"error":"invalid_grant"}`, "", ErrTokenRevoked, false},
+		{"token endpoint unavailable", oauth2.Token{AccessToken: "old", RefreshToken: "r", Expiry: valid},
+			http.StatusServiceUnavailable, `{"error":"unavailable"}`, "", nil, true},
+		{"access token only, valid", oauth2.Token{AccessToken: "old", Expiry: valid},
+			http.StatusOK, "", "old", nil, false},
+		{"access token only, revoked", oauth2.Token{AccessToken: "old", Expiry: valid},
+			http.StatusBadRequest, "", "", ErrTokenRevoked, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+				switch r.URL.Path {
+				case "/token":
+					if r.Form.Get("refresh_token") != "r" {
+						t.Errorf("refresh_token = %q", r.Form.Get("refresh_token"))
+					}
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(tt.status)
+					w.Write([]byte(tt.body))
+				case "/tokeninfo":
+					if r.Form.Get("access_token") != "old" {
+						t.Errorf("access_token = %q", r.Form.Get("access_token"))
+					}
+					w.WriteHeader(tt.status)
+				}
+			}))
+			defer srv.Close()
+			defer func(u string) { tokeninfoURL = u }(tokeninfoURL)
+			tokeninfoURL = srv.URL + "/tokeninfo"
+
+			config := &oauth2.Config{ClientID: "id", Endpoint: oauth2.Endpoint{TokenURL: srv.URL + "/token", AuthStyle: oauth2.AuthStyleInParams}}
+			tokFile := filepath.Join(t.TempDir(), "token.json")
+			if err := SaveToken(tokFile, &tt.tok); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := CheckToken(context.Background(), config, tokFile, &tt.tok)
+			switch {
+			case tt.otherFail:
+				if err == nil || errors.Is(err, ErrTokenRevoked) {
+					t.Fatalf("err = %v, want a non-revocation error", err)
+				}
+				return
+			case !errors.Is(err, tt.wantErr):
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			case err != nil:
+				return
+			}
+			if got.AccessToken != tt.want {
+				t.Errorf("AccessToken = %q, want %q", got.AccessToken, tt.want)
+			}
+			saved, err := LoadToken(tokFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved.AccessToken != tt.want || saved.RefreshToken != tt.tok.RefreshToken {
+				t.Errorf("token salvo = %+v, want access token %q", saved, tt.want)
+			}
+		})
+	}
+}